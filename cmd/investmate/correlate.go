@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/renderers"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultCorrelationThreshold is the smallest correlation of two ETFs considered the same exposure.
+	defaultCorrelationThreshold = 0.9
+)
+
+// correlateOptions holds the flags of the correlate command.
+type correlateOptions struct {
+	*globalOptions
+
+	tickers   []string
+	list      string
+	format    string
+	threshold float64
+}
+
+// resolve picks the tickers of the arguments, or of the selected watchlist, or the built-in watchlist, and applies
+// the settings of the selected watchlist to the flags that were not set explicitly.
+func (o *correlateOptions) resolve(command *cobra.Command, args []string) error {
+	o.tickers = args
+	if o.list == "" {
+		if len(o.tickers) == 0 {
+			o.tickers = defaultTickers()
+		}
+
+		return nil
+	}
+
+	watchlist, err := o.config.Watchlist(o.list)
+	if err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+	if len(o.tickers) == 0 {
+		o.tickers = watchlist.Tickers
+	}
+
+	if !flags.Changed("years") && watchlist.Years != 0 {
+		o.years = watchlist.Years
+	}
+
+	if !flags.Changed("provider") && len(watchlist.Provider) > 0 {
+		o.providers = watchlist.Provider
+	}
+
+	return o.validate()
+}
+
+// newCorrelateCommand creates the command that renders the correlation matrix of the daily returns of the watchlist.
+func newCorrelateCommand(global *globalOptions) *cobra.Command {
+	options := &correlateOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "correlate [TICKER...]",
+		Short: "Render the correlation matrix of the daily returns of the watchlist",
+		Long: "Render the correlation matrix of the daily total returns of the watchlist, aligned on the dates every " +
+			"pair traded.\nThe pairs correlated at or above --threshold are likely the same exposure.",
+		RunE: func(command *cobra.Command, args []string) error {
+			if err := options.resolve(command, args); err != nil {
+				return err
+			}
+
+			return runCorrelate(command.Context(), command.OutOrStdout(), options)
+		},
+	}
+
+	command.Flags().StringVar(&options.list, "list", "", "name of the watchlist defined in the config file")
+	command.Flags().StringVar(
+		&options.format, "format", renderers.FormatTable,
+		"output format, one of: "+strings.Join(renderers.Formats(), ", "),
+	)
+	command.Flags().Float64Var(
+		&options.threshold, "threshold", defaultCorrelationThreshold,
+		"smallest correlation of two ETFs considered the same exposure",
+	)
+
+	return command
+}
+
+// runCorrelate fetches the price history and the dividends of every ticker and renders the correlation matrix of
+// their total returns over the years of the report. The tickers whose prices fail are left out of the matrix and
+// their errors are returned after rendering the others.
+func runCorrelate(ctx context.Context, writer io.Writer, options *correlateOptions) error {
+	renderer, err := renderers.NewCorrelationRenderer(options.format)
+	if err != nil {
+		return err
+	}
+
+	repos, err := newRepositories(options.globalOptions)
+	if err != nil {
+		return err
+	}
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	fetched, errs := fetchPriceHistories(fetchCtx, options.tickers, repos, options.globalOptions, true)
+
+	etfs := make([]*entities.ETF, 0, len(fetched))
+	for _, etf := range fetched {
+		if etf != nil {
+			etfs = append(etfs, etf)
+		}
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year()-options.years+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	matrix := entities.NewCorrelationMatrix(etfs, start, now)
+
+	for _, pair := range matrix.PairsAbove(options.threshold) {
+		logger.Warnf(
+			"%s and %s are %.2f correlated over %d days, likely the same exposure",
+			pair.First, pair.Second, pair.Correlation.Coefficient, pair.Correlation.Observations,
+		)
+	}
+
+	report := renderers.CorrelationReport{Matrix: matrix, Threshold: options.threshold}
+	if err = renderer.RenderCorrelations(writer, report); err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
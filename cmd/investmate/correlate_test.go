@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelate_Resolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should correlate the built-in watchlist when no tickers are given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &correlateOptions{globalOptions: &globalOptions{config: &config.Config{}}}
+		command := newCorrelateCommand(options.globalOptions)
+
+		// when
+		err := options.resolve(command, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, defaultTickers(), options.tickers)
+	})
+
+	t.Run("should apply the tickers and the years of the selected watchlist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &correlateOptions{
+			globalOptions: &globalOptions{
+				years:          defaultYearsToFetch,
+				providers:      []string{providerNasdaq},
+				concurrency:    defaultConcurrency,
+				timeout:        defaultTimeout,
+				requestTimeout: defaultRequestTimeout,
+				config: &config.Config{
+					Watchlists: map[string]config.Watchlist{
+						"core-index": {Tickers: []string{"SPY", "VOO", "IVV"}, Years: 3},
+					},
+				},
+			},
+			list: "core-index",
+		}
+		command := newCorrelateCommand(options.globalOptions)
+
+		// when
+		err := options.resolve(command, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SPY", "VOO", "IVV"}, options.tickers)
+		assert.Equal(t, 3, options.years)
+	})
+
+	t.Run("should return an error when the watchlist is not defined", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("watchlists:\n  gold:\n    tickers: [GLD]\n"), 0o600))
+
+		command := newRootCommand()
+		command.SetArgs([]string{"correlate", "--config", path, "--list", "core-index"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.ErrorIs(t, err, config.ErrWatchlistNotFound)
+	})
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/renderers"
+	"github.com/spf13/cobra"
+)
+
+// dividendsOptions holds the flags of the dividends command.
+type dividendsOptions struct {
+	*globalOptions
+
+	payments bool
+}
+
+// newDividendsCommand creates the command that lists the dividends of the given tickers.
+func newDividendsCommand(global *globalOptions) *cobra.Command {
+	options := &dividendsOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "dividends TICKER [TICKER...]",
+		Short: "List the yearly dividend sums, or every payment, of the given tickers",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			return runDividends(command.Context(), command.OutOrStdout(), args, options)
+		},
+	}
+
+	command.Flags().BoolVar(&options.payments, "payments", false, "list every payment instead of the yearly sums")
+
+	return command
+}
+
+// runDividends fetches the dividends of every ticker and renders either one row per ticker or one row per payment.
+// The tickers that fail are left out of the table and their errors are returned after rendering the others.
+func runDividends(ctx context.Context, writer io.Writer, tickers []string, options *dividendsOptions) error {
+	repos, err := newRepositories(options.globalOptions)
+	if err != nil {
+		return err
+	}
+
+	year := currentYear()
+	table := tablewriter.NewWriter(writer)
+
+	if options.payments {
+		table.Header(
+			"ETF", "Ex-Date", "Record Date", "Declaration Date", "Payment Date", "Amount", "Change",
+		)
+	} else {
+		table.Header(append(yearHeaders(year, options.years), "Averages", "Next Ex-Date", "Last Change"))
+	}
+
+	etfs := make([]*entities.ETF, len(tickers))
+	errs := make([]error, len(tickers))
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	called := forEachConcurrently(fetchCtx, len(tickers), options.concurrency, func(index int) {
+		name := strings.ToUpper(tickers[index])
+
+		requestCtx, cancelRequest := context.WithTimeout(fetchCtx, options.requestTimeout)
+		defer cancelRequest()
+
+		// The splits come first, since a new split invalidates the cached dividends.
+		splits, splitsErr := repos.splits.ListSplitsByETF(requestCtx, name)
+
+		dividends, listErr := repos.dividends.ListDividendsByETF(requestCtx, name)
+		if listErr != nil {
+			errs[index] = fmt.Errorf("failed to fetch dividends for ETF %s: %w", name, listErr)
+			return
+		}
+
+		etfs[index] = entities.NewETF(name)
+		etfs[index].SetDividends(dividends)
+
+		// Without the splits the payments are still shown, just not adjusted, like in processETF.
+		if splitsErr != nil {
+			errs[index] = fmt.Errorf("failed to fetch splits for ETF %s, left unadjusted: %w", name, splitsErr)
+		}
+
+		etfs[index].AdjustForSplits(splits)
+	})
+
+	for index, wasCalled := range called {
+		if !wasCalled {
+			errs[index] = fmt.Errorf("%w: %s: %w", errSkipped, tickers[index], context.Cause(fetchCtx))
+		}
+	}
+
+	if err = convertCurrencies(fetchCtx, etfs, options.globalOptions); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, etf := range etfs {
+		if etf == nil {
+			continue
+		}
+
+		name := etf.Name
+
+		var rows [][]string
+		if options.payments {
+			rows = paymentRows(etf, year-options.years+1)
+		} else {
+			nextExDate, _ := etf.NextExDate(time.Now())
+
+			row := []string{name}
+			row = append(row, etf.ShowDividendsPerYear(year, options.years)...)
+			row = append(row, etf.FormatAmount(etf.AverageDividends(year, options.years)))
+			row = append(row, showDate(nextExDate), renderers.ShowValue("%+.2f%%", etf.LastPaymentChangePercentage))
+			rows = [][]string{row}
+		}
+
+		if err = table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to append dividend rows for ETF %s: %w", name, err)
+		}
+	}
+
+	if err = table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// paymentRows builds one row per payment made since the first year, with the change from the previous payment.
+func paymentRows(etf *entities.ETF, firstYear int) [][]string {
+	var rows [][]string
+
+	for i, dividend := range etf.Dividends {
+		if dividend.Date().Year() < firstYear {
+			continue
+		}
+
+		rows = append(rows, []string{
+			etf.Name,
+			showDate(dividend.ExDate),
+			showDate(dividend.RecordDate),
+			showDate(dividend.DeclarationDate),
+			showDate(dividend.PaymentDate),
+			fmt.Sprintf("%s%.4f", entities.CurrencySymbol(etf.Currency), dividend.Amount),
+			renderers.ShowValue("%+.2f%%", func() (float64, bool) { return etf.PaymentChangePercentage(i) }),
+		})
+	}
+
+	return rows
+}
+
+// showDate formats a date for table display, using a dash when the provider did not report it.
+func showDate(date time.Time) string {
+	if date.IsZero() {
+		return "-"
+	}
+
+	return date.Format(time.DateOnly)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDividends_YearlySums(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the next announced ex-date and the change of the last payment", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		nextExDate := today.AddDate(0, 0, 10)
+		content, err := json.Marshal(map[string]any{
+			"fetched_at": time.Now(),
+			"records": []entities.Dividend{
+				{ExDate: today.AddDate(0, 0, -20), PaymentDate: today.AddDate(0, 0, -15), Amount: 0.4},
+				{ExDate: nextExDate, PaymentDate: today.AddDate(0, 0, 15), Amount: 0.5},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(directory, "cache", "dividends", providerNasdaq), 0o755))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(directory, "cache", "dividends", providerNasdaq, "JEPI.json"), content, 0o600,
+		))
+
+		configPath := filepath.Join(directory, "config.yaml")
+		require.NoError(t, os.WriteFile(
+			configPath, []byte("cache:\n  directory: "+filepath.Join(directory, "cache")+"\n"), 0o600,
+		))
+
+		var buffer bytes.Buffer
+
+		command := newRootCommand()
+		command.SetOut(&buffer)
+		command.SetArgs([]string{"dividends", "JEPI", "--config", configPath, "--offline"})
+
+		// when
+		err = command.Execute()
+
+		// then
+		require.ErrorIs(t, err, cache.ErrNotCached)
+		assert.Contains(t, buffer.String(), "LAST CHANGE")
+		assert.Contains(t, buffer.String(), nextExDate.Format(time.DateOnly))
+		assert.Contains(t, buffer.String(), "+25.00%")
+	})
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// errSkipped is returned for the tickers that were not fetched because the context was canceled first.
+var errSkipped = errors.New("skipped before fetching")
+
+// fetchResult holds the ETF fetched for a ticker and the errors of the repositories that failed.
+type fetchResult struct {
+	etf *entities.ETF
+	err error
+}
+
+// forEachConcurrently calls the function for every index from zero to total, running at most concurrency calls
+// at the same time, and returns once all of them finished. Once the context is canceled no further calls start,
+// and the returned slice flags which indexes were called.
+func forEachConcurrently(ctx context.Context, total, concurrency int, function func(index int)) []bool {
+	var waitGroup sync.WaitGroup
+
+	called := make([]bool, total)
+	semaphore := make(chan struct{}, concurrency)
+
+	for index := range total {
+		select {
+		case <-ctx.Done():
+			waitGroup.Wait()
+			return called
+		case semaphore <- struct{}{}:
+		}
+
+		called[index] = true
+
+		waitGroup.Go(func() {
+			defer func() { <-semaphore }()
+
+			function(index)
+		})
+	}
+
+	waitGroup.Wait()
+
+	return called
+}
+
+// fetchETFs processes every ticker with a bounded number of workers.
+// The results keep the order of the tickers, regardless of the order the fetches finish.
+func fetchETFs(
+	ctx context.Context,
+	names []string,
+	repos *repositorySet,
+	concurrency int,
+	requestTimeout time.Duration,
+) []fetchResult {
+	results := make([]fetchResult, len(names))
+
+	called := forEachConcurrently(ctx, len(names), concurrency, func(index int) {
+		etf, err := processETF(ctx, names[index], repos, requestTimeout)
+		results[index] = fetchResult{etf: etf, err: err}
+	})
+
+	for index, wasCalled := range called {
+		if !wasCalled {
+			results[index] = fetchResult{
+				etf: entities.NewETF(names[index]),
+				err: fmt.Errorf("%w: %w", errSkipped, context.Cause(ctx)),
+			}
+		}
+	}
+
+	return results
+}
+
+// processETF populates an ETF struct with dividend payments, daily prices and fundamentals, fetching them
+// concurrently with their own request deadline once the splits are known, and adjusts the payments and prices for
+// the splits. The ETF keeps whatever data was fetched, and the failures are joined into the returned error.
+func processETF(
+	ctx context.Context,
+	name string,
+	repos *repositorySet,
+	requestTimeout time.Duration,
+) (*entities.ETF, error) {
+	var (
+		waitGroup                                           sync.WaitGroup
+		dividends                                           []entities.Dividend
+		priceBars                                           []entities.PriceBar
+		fundamentals                                        entities.Fundamentals
+		splits                                              []entities.Split
+		dividendsErr, pricesErr, fundamentalsErr, splitsErr error
+	)
+
+	// The splits come first, since a new split invalidates the cached prices and dividends.
+	if repos.splits != nil {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		splits, splitsErr = repos.splits.ListSplitsByETF(requestCtx, name)
+		cancel()
+	}
+
+	waitGroup.Go(func() {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		dividends, dividendsErr = repos.dividends.ListDividendsByETF(requestCtx, name)
+	})
+	waitGroup.Go(func() {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		priceBars, pricesErr = repos.prices.ListPriceBarsByETF(requestCtx, name)
+	})
+	waitGroup.Go(func() {
+		requestCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		fundamentals, fundamentalsErr = repos.fundamentals.GetFundamentalsByETF(requestCtx, name)
+	})
+
+	waitGroup.Wait()
+
+	etf := entities.NewETF(name)
+	etf.Fundamentals = fundamentals
+	etf.SetDividends(dividends)
+	etf.SetPriceBars(priceBars)
+	etf.AdjustForSplits(splits)
+
+	var errs []error
+	if dividendsErr != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch dividends: %w", dividendsErr))
+	}
+
+	if pricesErr != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch prices: %w", pricesErr))
+	}
+
+	if fundamentalsErr != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch fundamentals: %w", fundamentalsErr))
+	}
+
+	if splitsErr != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch splits: %w", splitsErr))
+	}
+
+	return etf, errors.Join(errs...)
+}
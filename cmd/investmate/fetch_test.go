@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDividendsRepository struct {
+	data   []entities.Dividend
+	err    error
+	delays map[string]time.Duration
+}
+
+func (s *stubDividendsRepository) ListDividendsByETF(_ context.Context, etf string) ([]entities.Dividend, error) {
+	if s.delays != nil {
+		time.Sleep(s.delays[etf])
+	}
+
+	return s.data, s.err
+}
+
+type stubPricesRepository struct {
+	data []entities.PriceBar
+	err  error
+}
+
+func (s *stubPricesRepository) ListPriceBarsByETF(_ context.Context, _ string) ([]entities.PriceBar, error) {
+	return s.data, s.err
+}
+
+type stubFundamentalsRepository struct {
+	data entities.Fundamentals
+	err  error
+}
+
+func (s *stubFundamentalsRepository) GetFundamentalsByETF(_ context.Context, _ string) (entities.Fundamentals, error) {
+	return s.data, s.err
+}
+
+type stubSplitsRepository struct {
+	data []entities.Split
+	err  error
+}
+
+func (s *stubSplitsRepository) ListSplitsByETF(_ context.Context, _ string) ([]entities.Split, error) {
+	return s.data, s.err
+}
+
+func TestFetch_ProcessETF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should populate ETF with data when repositories return valid results", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		dividendsRepo := &stubDividendsRepository{
+			data: []entities.Dividend{
+				{PaymentDate: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), Amount: 2.50},
+				{PaymentDate: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Amount: 4.80},
+				{PaymentDate: time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC), Amount: 3.00},
+			},
+		}
+		pricesRepo := &stubPricesRepository{
+			data: []entities.PriceBar{
+				{Date: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), Close: 440.00},
+				{Date: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Close: 420.00},
+				{Date: time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), Close: 460.00},
+			},
+		}
+		fundamentalsRepo := &stubFundamentalsRepository{
+			data: entities.Fundamentals{PayoutFrequency: "Quarterly", ExpenseRatio: 0.09},
+		}
+
+		// when
+		etf, err := processETF(context.Background(), "SPY", &repositorySet{
+			dividends:    dividendsRepo,
+			prices:       pricesRepo,
+			fundamentals: fundamentalsRepo,
+		}, time.Second)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "SPY", etf.Name)
+		assert.NotEmpty(t, etf.AmountDividendsPerYear)
+		assert.NotEmpty(t, etf.AverageClosingPricePerYear)
+		assert.InDelta(t, 5.50, etf.AmountDividendsPerYear["2025"], 0.001)
+		assert.Len(t, etf.Dividends, 3)
+		assert.Len(t, etf.PriceBars, 3)
+		assert.InDelta(t, 450.00, etf.AverageClosingPricePerYear["2025"], 0.001)
+		assert.Equal(t, "Quarterly", etf.PayoutFrequency)
+		assert.InDelta(t, 0.09, etf.ExpenseRatio, 0.001)
+	})
+
+	t.Run("should return ETF with empty maps and every error when repositories return errors", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		dividendsRepo := &stubDividendsRepository{
+			err: errors.New("network error"),
+		}
+		pricesRepo := &stubPricesRepository{
+			err: errors.New("network error"),
+		}
+		fundamentalsRepo := &stubFundamentalsRepository{
+			err: errors.New("network error"),
+		}
+
+		// when
+		etf, err := processETF(context.Background(), "INVALID", &repositorySet{
+			dividends:    dividendsRepo,
+			prices:       pricesRepo,
+			fundamentals: fundamentalsRepo,
+		}, time.Second)
+
+		// then
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch dividends")
+		assert.ErrorContains(t, err, "failed to fetch prices")
+		assert.ErrorContains(t, err, "failed to fetch fundamentals")
+		assert.Empty(t, etf.AmountDividendsPerYear)
+		assert.Empty(t, etf.AverageClosingPricePerYear)
+		assert.Equal(t, entities.Fundamentals{}, etf.Fundamentals)
+	})
+
+	t.Run("should adjust the payments and prices before a reverse split to the current share terms", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		splitDate := time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC)
+		repos := &repositorySet{
+			dividends: &stubDividendsRepository{data: []entities.Dividend{
+				{ExDate: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), Amount: 0.30},
+			}},
+			prices: &stubPricesRepository{data: []entities.PriceBar{
+				{Date: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), Close: 20.00},
+				{Date: splitDate, Close: 80.00},
+			}},
+			fundamentals: &stubFundamentalsRepository{},
+			splits:       &stubSplitsRepository{data: []entities.Split{{Date: splitDate, Numerator: 1, Denominator: 4}}},
+		}
+
+		// when
+		etf, err := processETF(context.Background(), "SVOL", repos, time.Second)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, 1.20, etf.AmountDividendsPerYear["2025"], 0.001)
+		assert.InDelta(t, 80.00, etf.AverageClosingPricePerYear["2025"], 0.001)
+	})
+}
+
+func TestFetch_FetchETFs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep the order of the tickers regardless of the order the fetches finish", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repos := &repositorySet{
+			dividends: &stubDividendsRepository{
+				delays: map[string]time.Duration{"SPY": 30 * time.Millisecond, "QQQ": 10 * time.Millisecond},
+			},
+			prices:       &stubPricesRepository{},
+			fundamentals: &stubFundamentalsRepository{err: errors.New("network error")},
+		}
+
+		// when
+		results := fetchETFs(context.Background(), []string{"SPY", "QQQ", "GLD"}, repos, 3, time.Second)
+
+		// then
+		require.Len(t, results, 3)
+		assert.Equal(t, "SPY", results[0].etf.Name)
+		assert.Equal(t, "QQQ", results[1].etf.Name)
+		assert.Equal(t, "GLD", results[2].etf.Name)
+		assert.ErrorContains(t, results[2].err, "failed to fetch fundamentals")
+	})
+}
+
+func TestFetch_ForEachConcurrently(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should never run more calls at the same time than the concurrency limit", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var running, peak atomic.Int32
+
+		// when
+		forEachConcurrently(context.Background(), 10, 2, func(_ int) {
+			current := running.Add(1)
+			for {
+				observed := peak.Load()
+				if current <= observed || peak.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		})
+
+		// then
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+		assert.Equal(t, int32(0), running.Load())
+	})
+}
+
+func TestFetch_FetchETFsCanceled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should skip the tickers not started before the context was canceled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		repos := &repositorySet{
+			dividends:    &stubDividendsRepository{},
+			prices:       &stubPricesRepository{},
+			fundamentals: &stubFundamentalsRepository{},
+		}
+
+		// when
+		results := fetchETFs(ctx, []string{"SPY", "QQQ"}, repos, 1, time.Second)
+
+		// then
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.NotNil(t, result.etf)
+			if result.err != nil {
+				assert.ErrorIs(t, result.err, context.Canceled)
+			}
+		}
+		assert.ErrorIs(t, results[1].err, errSkipped)
+	})
+}
+
+func TestFetch_ProcessETFTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should stop waiting for a repository once the request deadline expires", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repos := &repositorySet{
+			dividends:    &blockingDividendsRepository{},
+			prices:       &stubPricesRepository{},
+			fundamentals: &stubFundamentalsRepository{},
+		}
+
+		// when
+		_, err := processETF(context.Background(), "SPY", repos, 10*time.Millisecond)
+
+		// then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// blockingDividendsRepository blocks until the context is done, like a hung provider.
+type blockingDividendsRepository struct{}
+
+func (s *blockingDividendsRepository) ListDividendsByETF(ctx context.Context, _ string) ([]entities.Dividend, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
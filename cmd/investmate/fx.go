@@ -0,0 +1,165 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/bcb"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/cache"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/csvrates"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// fxProviderPTAX selects the PTAX rates of the Brazilian central bank, which are quoted against BRL.
+	fxProviderPTAX = "ptax"
+
+	// fxProviderCSV selects the exchange rates read from the source configured in the fx section.
+	fxProviderCSV = "csv"
+
+	// fxRateGap is the longest time without a rate expected from a provider, such as over a holiday week.
+	fxRateGap = 7 * 24 * time.Hour
+)
+
+var (
+	// errUnknownFXProvider is returned when the configured exchange rates provider is not supported.
+	errUnknownFXProvider = errors.New("unknown exchange rates provider")
+
+	// errMissingFXSource is returned when the CSV exchange rates are selected without a source in the config file.
+	errMissingFXSource = errors.New("the csv exchange rates provider requires the fx.source setting")
+)
+
+// newFXRatesRepository creates the exchange rates repository selected in the fx section of the config file,
+// decorated with the on-disk cache unless it is disabled or the rates are read from a local file.
+func newFXRatesRepository(options *globalOptions, from time.Time) (repositories.FXRatesRepository, error) {
+	settings := options.config.FX
+
+	var repository repositories.FXRatesRepository
+
+	switch settings.Provider {
+	case "", fxProviderPTAX:
+		repository = bcb.NewPTAXRatesRepository()
+	case fxProviderCSV:
+		if settings.Source == "" {
+			return nil, errMissingFXSource
+		}
+
+		csvRepository := csvrates.NewCSVRatesRepository(csvrates.Options{
+			Source:       settings.Source,
+			DateFormat:   settings.DateFormat,
+			Delimiter:    delimiterOf(settings.Delimiter),
+			DecimalComma: settings.DecimalComma,
+			DateColumn:   settings.DateColumn,
+			RateColumn:   settings.RateColumn,
+		})
+		if csvRepository.IsLocal() {
+			return csvRepository, nil
+		}
+
+		repository = csvRepository
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownFXProvider, settings.Provider)
+	}
+
+	if options.noCache {
+		return repository, nil
+	}
+
+	store, err := newStore(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rates are cached per first year fetched, so a longer history is not served the rates of a shorter one, and
+	// refreshed as often as the prices.
+	namespace := fmt.Sprintf("%s-%d", cmp.Or(settings.Provider, fxProviderPTAX), from.Year())
+
+	return cache.NewCachedFXRatesRepository(
+		repository, store, namespace, durationOr(options.config.Cache.PricesTTL, defaultPricesTTL), options.offline,
+	), nil
+}
+
+// convertCurrencies converts the ETFs into the report currency with the rates of each native currency since the
+// year of the oldest record to convert, since the whole history feeds the yearly figures. The ETFs whose rates cannot
+// be fetched keep their native currency, and the records older than the first rate are converted at that rate with a
+// warning.
+func convertCurrencies(ctx context.Context, etfs []*entities.ETF, options *globalOptions) error {
+	if options.reportCurrency == "" {
+		return nil
+	}
+
+	now := time.Now()
+	oldest := oldestRecordDate(etfs, options.reportCurrency, now)
+	from := time.Date(oldest.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	repository, err := newFXRatesRepository(options, from)
+	if err != nil {
+		return err
+	}
+
+	series := make(map[string]entities.FXSeries)
+
+	var errs []error
+
+	for _, etf := range etfs {
+		if etf == nil || etf.Currency == options.reportCurrency {
+			continue
+		}
+
+		rates, fetched := series[etf.Currency]
+		if !fetched {
+			requestCtx, cancel := context.WithTimeout(ctx, options.requestTimeout)
+			list, listErr := repository.ListRatesByPair(requestCtx, etf.Currency, options.reportCurrency, from, now)
+			cancel()
+
+			if listErr != nil {
+				errs = append(errs, fmt.Errorf(
+					"failed to fetch the %s/%s exchange rates: %w", etf.Currency, options.reportCurrency, listErr,
+				))
+			}
+
+			rates = entities.NewFXSeries(list)
+			series[etf.Currency] = rates
+
+			if len(rates) > 0 && rates[0].Date.Sub(oldest) > fxRateGap {
+				logger.Warnf(
+					"The %s/%s exchange rates start on %s, the older records are converted at that rate",
+					etf.Currency, options.reportCurrency, rates[0].Date.Format(time.DateOnly),
+				)
+			}
+		}
+
+		etf.ConvertCurrency(options.reportCurrency, rates)
+	}
+
+	return errors.Join(errs...)
+}
+
+// oldestRecordDate returns the date of the oldest dividend or price bar among the ETFs not in the currency yet, or
+// the given moment when there is none.
+func oldestRecordDate(etfs []*entities.ETF, currency string, now time.Time) time.Time {
+	oldest := now
+
+	for _, etf := range etfs {
+		if etf == nil || etf.Currency == currency {
+			continue
+		}
+
+		for _, dividend := range etf.Dividends {
+			if date := dividend.Date(); !date.IsZero() && date.Before(oldest) {
+				oldest = date
+			}
+		}
+
+		if len(etf.PriceBars) > 0 && etf.PriceBars[0].Date.Before(oldest) {
+			oldest = etf.PriceBars[0].Date
+		}
+	}
+
+	return oldest
+}
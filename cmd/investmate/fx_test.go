@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFX_ConvertCurrencies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should convert only the ETFs quoted in another currency with the rates of the local CSV", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		content := "Date,Rate\n" + time.Now().Format(time.DateOnly) + ",5.0\n"
+		require.NoError(t, os.WriteFile(filepath.Join(directory, "USD_BRL.csv"), []byte(content), 0o600))
+		options := &globalOptions{
+			years:          defaultYearsToFetch,
+			requestTimeout: defaultRequestTimeout,
+			reportCurrency: entities.CurrencyBRL,
+			offline:        true,
+			config: &config.Config{
+				FX: config.FX{Provider: fxProviderCSV, Source: filepath.Join(directory, "{base}_{quote}.csv")},
+			},
+		}
+		spy, mxrf := entities.NewETF("SPY"), entities.NewETF("MXRF11")
+		spy.SetDividends([]entities.Dividend{{PaymentDate: time.Now(), Amount: 1}})
+		mxrf.SetDividends([]entities.Dividend{{PaymentDate: time.Now(), Amount: 1}})
+
+		// when
+		err := convertCurrencies(context.Background(), []*entities.ETF{spy, nil, mxrf}, options)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, entities.CurrencyBRL, spy.Currency)
+		assert.InDelta(t, 5.0, spy.Dividends[0].Amount, 0.001)
+		assert.InDelta(t, 1.0, mxrf.Dividends[0].Amount, 0.001)
+	})
+
+	t.Run("should fetch the rates since the oldest record instead of the years of the report", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		content := "Date,Rate\n2015-01-02,3.0\n" + time.Now().Format(time.DateOnly) + ",5.0\n"
+		require.NoError(t, os.WriteFile(filepath.Join(directory, "USD_BRL.csv"), []byte(content), 0o600))
+		options := &globalOptions{
+			years:          1,
+			requestTimeout: defaultRequestTimeout,
+			reportCurrency: entities.CurrencyBRL,
+			offline:        true,
+			config: &config.Config{
+				FX: config.FX{Provider: fxProviderCSV, Source: filepath.Join(directory, "{base}_{quote}.csv")},
+			},
+		}
+		spy := entities.NewETF("SPY")
+		spy.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2015, time.March, 31, 0, 0, 0, 0, time.UTC), Amount: 1},
+			{PaymentDate: time.Now(), Amount: 1},
+		})
+
+		// when
+		err := convertCurrencies(context.Background(), []*entities.ETF{spy}, options)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, 3.0, spy.Dividends[0].Amount, 0.001)
+		assert.InDelta(t, 5.0, spy.Dividends[1].Amount, 0.001)
+	})
+
+	t.Run("should reject an unknown exchange rates provider", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{
+			reportCurrency: entities.CurrencyBRL,
+			noCache:        true,
+			config:         &config.Config{FX: config.FX{Provider: "unknown"}},
+		}
+
+		// when
+		err := convertCurrencies(context.Background(), []*entities.ETF{entities.NewETF("SPY")}, options)
+
+		// then
+		require.ErrorIs(t, err, errUnknownFXProvider)
+	})
+}
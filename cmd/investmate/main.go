@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultYearsToFetch is the number of years to fetch data for when the flag is not provided.
+	defaultYearsToFetch = 5
+
+	// defaultConcurrency is the number of tickers fetched at the same time when the flag is not provided.
+	defaultConcurrency = 4
+
+	// defaultTimeout is the deadline of the whole command when the flag is not provided.
+	defaultTimeout = 5 * time.Minute
+
+	// defaultRequestTimeout is the deadline of each repository request when the flag is not provided.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+var (
+	// errInvalidYears is returned when the number of years to fetch is not a positive number.
+	errInvalidYears = errors.New("the number of years must be greater than zero")
+
+	// errInvalidConcurrency is returned when the number of concurrent fetches is not a positive number.
+	errInvalidConcurrency = errors.New("the concurrency must be greater than zero")
+
+	// errInvalidTimeout is returned when a timeout is not a positive duration.
+	errInvalidTimeout = errors.New("the timeout must be greater than zero")
+
+	// errOfflineWithoutCache is returned when the offline mode is requested with the cache disabled.
+	errOfflineWithoutCache = errors.New("the offline mode requires the cache")
+)
+
+// globalOptions holds the flags shared by every subcommand and the loaded configuration file.
+type globalOptions struct {
+	configPath  string
+	years       int
+	providers   []string
+	concurrency int
+
+	reportCurrency string
+
+	timeout        time.Duration
+	requestTimeout time.Duration
+
+	offline bool
+	noCache bool
+
+	config *config.Config
+}
+
+// load reads the configuration file and applies its top-level settings to the flags that were not set.
+func (o *globalOptions) load(command *cobra.Command) error {
+	var err error
+
+	if o.configPath != "" {
+		o.config, err = config.Load(o.configPath)
+	} else {
+		o.config, err = config.LoadDefault()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !command.Flags().Changed("years") && o.config.Years != 0 {
+		o.years = o.config.Years
+	}
+
+	if !command.Flags().Changed("provider") && len(o.config.Provider) > 0 {
+		o.providers = o.config.Provider
+	}
+
+	if !command.Flags().Changed("report-currency") && o.config.ReportCurrency != "" {
+		o.reportCurrency = o.config.ReportCurrency
+	}
+
+	o.reportCurrency = strings.ToUpper(o.reportCurrency)
+
+	if !command.Flags().Changed("concurrency") && o.config.Concurrency != 0 {
+		o.concurrency = o.config.Concurrency
+	}
+
+	if !command.Flags().Changed("timeout") && o.config.Timeout != 0 {
+		o.timeout = o.config.Timeout
+	}
+
+	if !command.Flags().Changed("request-timeout") && o.config.RequestTimeout != 0 {
+		o.requestTimeout = o.config.RequestTimeout
+	}
+
+	return o.validate()
+}
+
+// withTimeout derives the context of a command, bounded by the global deadline.
+func (o *globalOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// validate checks the shared flags before any subcommand runs.
+func (o *globalOptions) validate() error {
+	if o.years <= 0 {
+		return fmt.Errorf("%w: %d", errInvalidYears, o.years)
+	}
+
+	if o.concurrency <= 0 {
+		return fmt.Errorf("%w: %d", errInvalidConcurrency, o.concurrency)
+	}
+
+	if o.timeout <= 0 || o.requestTimeout <= 0 {
+		return fmt.Errorf("%w: %s and %s", errInvalidTimeout, o.timeout, o.requestTimeout)
+	}
+
+	if o.offline && o.noCache {
+		return errOfflineWithoutCache
+	}
+
+	return nil
+}
+
+// yearHeaders builds the table headers for the ETF column followed by each year, from the most recent backwards.
+func yearHeaders(currentYear, totalYears int) []string {
+	headers := []string{"ETF"}
+
+	for i := range totalYears {
+		headers = append(headers, strconv.Itoa(currentYear-i))
+	}
+
+	return headers
+}
+
+// newRootCommand creates the top-level command and registers every subcommand.
+func newRootCommand() *cobra.Command {
+	options := &globalOptions{}
+
+	command := &cobra.Command{
+		Use:           "investmate",
+		Short:         "Fetch, process and display ETF dividend and price data",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(command *cobra.Command, _ []string) error {
+			return options.load(command)
+		},
+	}
+
+	command.PersistentFlags().StringVar(
+		&options.configPath, "config", "", "path to the config file (defaults to the user config directory)",
+	)
+	command.PersistentFlags().IntVar(&options.years, "years", defaultYearsToFetch, "number of years to fetch and display")
+	command.PersistentFlags().StringSliceVar(
+		&options.providers, "provider", []string{providerNasdaq},
+		"comma-separated data providers, tried in order when the previous ones fail or miss some years",
+	)
+	command.PersistentFlags().StringVar(
+		&options.reportCurrency, "report-currency", "",
+		"ISO 4217 code of the currency the amounts are converted into (defaults to the currency of each ETF)",
+	)
+	command.PersistentFlags().IntVar(
+		&options.concurrency, "concurrency", defaultConcurrency, "maximum number of tickers fetched at the same time",
+	)
+	command.PersistentFlags().DurationVar(&options.timeout, "timeout", defaultTimeout, "deadline of the whole command")
+	command.PersistentFlags().DurationVar(
+		&options.requestTimeout, "request-timeout", defaultRequestTimeout, "deadline of each request to a provider",
+	)
+	command.PersistentFlags().BoolVar(&options.offline, "offline", false, "serve only from the cache, without any request")
+	command.PersistentFlags().BoolVar(&options.noCache, "no-cache", false, "always fetch from the providers")
+
+	command.AddCommand(
+		newReportCommand(options),
+		newDividendsCommand(options),
+		newPricesCommand(options),
+		newReconcileCommand(options),
+		newCorrelateCommand(options),
+		newPortfolioCommand(options),
+	)
+
+	return command
+}
+
+// currentYear returns the year the report is anchored to.
+func currentYear() int {
+	return time.Now().Year()
+}
+
+func main() {
+	// Ctrl-C cancels the in-flight requests, the commands still render whatever data was already gathered.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := newRootCommand().ExecuteContext(ctx)
+	stop()
+
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to execute the command")
+	}
+}
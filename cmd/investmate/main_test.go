@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain_YearHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the years from the most recent backwards after the ETF column", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		currentYear := 2025
+
+		// when
+		result := yearHeaders(currentYear, 3)
+
+		// then
+		assert.Equal(t, []string{"ETF", "2025", "2024", "2023"}, result)
+	})
+}
+
+func TestMain_NewRootCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject a non-positive number of years", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		command := newRootCommand()
+		command.SetArgs([]string{"prices", "SPY", "--years", "0"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.ErrorIs(t, err, errInvalidYears)
+	})
+
+	t.Run("should reject the offline mode when the cache is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		command := newRootCommand()
+		command.SetArgs([]string{"prices", "SPY", "--offline", "--no-cache"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.ErrorIs(t, err, errOfflineWithoutCache)
+	})
+
+	t.Run("should require at least one ticker for the dividends command", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		command := newRootCommand()
+		command.SetArgs([]string{"dividends"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+	"github.com/rios0rios0/investmate/internal/infrastructure/renderers"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/csvholdings"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// calendarMonths is the number of months the income calendar projects, starting with the current one.
+	calendarMonths = 12
+)
+
+// errMissingHoldings is returned when the holdings file is given neither by the flag nor by the config file.
+var errMissingHoldings = errors.New("the portfolio requires the --holdings flag or the holdings.path setting")
+
+// portfolioOptions holds the flags of the portfolio command.
+type portfolioOptions struct {
+	*globalOptions
+
+	holdings string
+	calendar bool
+}
+
+// resolve picks the holdings file of the config file when the flag was not set explicitly.
+func (o *portfolioOptions) resolve(command *cobra.Command) error {
+	if !command.Flags().Changed("holdings") {
+		o.holdings = o.config.Holdings.Path
+	}
+
+	if o.holdings == "" {
+		return errMissingHoldings
+	}
+
+	return nil
+}
+
+// newPortfolioCommand creates the command that values the holdings and projects their income.
+func newPortfolioCommand(global *globalOptions) *cobra.Command {
+	options := &portfolioOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "portfolio",
+		Short: "Value the positions of the holdings file and project their dividend income",
+		Long: "Value the positions of the holdings file at the latest close and project their income from the " +
+			"forward dividends.\nThe holdings file is a CSV with the ticker, shares, cost_basis and optional " +
+			"account columns.\nWith --calendar, it projects the payments of the next twelve months instead, from the " +
+			"latest payment and the payout frequency of every ticker.",
+		Args: cobra.NoArgs,
+		RunE: func(command *cobra.Command, _ []string) error {
+			if err := options.resolve(command); err != nil {
+				return err
+			}
+
+			return runPortfolio(command.Context(), command.OutOrStdout(), options)
+		},
+	}
+
+	command.Flags().StringVar(
+		&options.holdings, "holdings", "", "path to the CSV holdings file (defaults to the holdings.path setting)",
+	)
+	command.Flags().BoolVar(
+		&options.calendar, "calendar", false, "render the income projected for each month and ticker instead",
+	)
+
+	return command
+}
+
+// newHoldingsRepository creates the repository of the holdings file, read with the settings of the holdings section
+// of the config file.
+func newHoldingsRepository(options *portfolioOptions) repositories.HoldingsRepository {
+	settings := options.config.Holdings
+
+	return csvholdings.NewCSVHoldingsRepository(csvholdings.Options{
+		Path:         options.holdings,
+		Delimiter:    delimiterOf(settings.Delimiter),
+		DecimalComma: settings.DecimalComma,
+	})
+}
+
+// runPortfolio reads the holdings, fetches every ticker and renders one row per position with the portfolio total.
+// The tickers that fail keep their cost basis in the table and their errors are returned after rendering.
+func runPortfolio(ctx context.Context, writer io.Writer, options *portfolioOptions) error {
+	holdings, err := newHoldingsRepository(options).ListHoldings(ctx)
+	if err != nil {
+		return err
+	}
+
+	repos, err := newRepositories(options.globalOptions)
+	if err != nil {
+		return err
+	}
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	tickers := entities.Tickers(holdings)
+	etfs := make([]*entities.ETF, 0, len(tickers))
+
+	var errs []error
+
+	for _, result := range fetchETFs(fetchCtx, tickers, repos, options.concurrency, options.requestTimeout) {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch data for ETF %s: %w", result.etf.Name, result.err))
+		}
+
+		etfs = append(etfs, result.etf)
+	}
+
+	if err = convertCurrencies(fetchCtx, etfs, options.globalOptions); err != nil {
+		errs = append(errs, err)
+	}
+
+	portfolio := entities.NewPortfolio(holdings, etfs)
+	if options.calendar {
+		err = renderIncomeCalendar(writer, entities.NewIncomeCalendar(portfolio, time.Now(), calendarMonths))
+	} else {
+		err = renderPortfolio(writer, portfolio)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+// renderPortfolio writes one row per position and the total of the portfolio. The weights and the total are only
+// shown when every position is in the same currency.
+func renderPortfolio(writer io.Writer, portfolio entities.Portfolio) error {
+	currency, sameCurrency := portfolio.Currency()
+
+	table := tablewriter.NewWriter(writer)
+	table.Header(
+		"ETF", "Account", "Shares", "Cost Basis", "Price", "Market Value",
+		"Weight", "Yield on Cost", "Annual Income", "Monthly Income",
+	)
+
+	rows := make([][]string, 0, len(portfolio.Positions)+1)
+	for _, position := range portfolio.Positions {
+		amount := entities.CurrencySymbol(position.ETF.Currency) + "%.2f"
+
+		weight := "-"
+		if sameCurrency {
+			weight = renderers.ShowValue("%.2f%%", func() (float64, bool) { return portfolio.Weight(position) })
+		}
+
+		rows = append(rows, []string{
+			position.Ticker,
+			cmp.Or(position.Account, "-"),
+			strconv.FormatFloat(position.Shares, 'f', -1, 64),
+			fmt.Sprintf(amount, position.CostBasis),
+			renderers.ShowValue(amount, func() (float64, bool) {
+				bar, exists := position.ETF.LastPriceBar()
+				return bar.Close, exists
+			}),
+			renderers.ShowValue(amount, position.MarketValue),
+			weight,
+			renderers.ShowValue("%.2f%%", position.YieldOnCost),
+			renderers.ShowValue(amount, position.AnnualIncome),
+			renderers.ShowValue(amount, position.MonthlyIncome),
+		})
+	}
+
+	total := []string{"Total", "", "", "-", "", "-", "-", "-", "-", "-"}
+	if sameCurrency {
+		amount := entities.CurrencySymbol(currency) + "%.2f"
+		total = []string{
+			"Total",
+			"",
+			"",
+			fmt.Sprintf(amount, portfolio.CostBasis()),
+			"",
+			fmt.Sprintf(amount, portfolio.MarketValue()),
+			"100.00%",
+			renderers.ShowValue("%.2f%%", portfolio.YieldOnCost),
+			fmt.Sprintf(amount, portfolio.AnnualIncome()),
+			fmt.Sprintf(amount, portfolio.MonthlyIncome()),
+		}
+	}
+
+	if err := table.Bulk(append(rows, total)); err != nil {
+		return fmt.Errorf("failed to append portfolio rows: %w", err)
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
+
+// renderIncomeCalendar writes one row per ticker with its income in each month and over the calendar, and the total
+// of each month, which is only added up when every ticker is in the same currency.
+func renderIncomeCalendar(writer io.Writer, calendar entities.IncomeCalendar) error {
+	headers := []string{"ETF"}
+	for _, month := range calendar.Months {
+		headers = append(headers, month.Format("Jan 2006"))
+	}
+
+	table := tablewriter.NewWriter(writer)
+	table.Header(append(headers, "Total"))
+
+	rows := make([][]string, 0, len(calendar.Rows)+1)
+	for _, income := range calendar.Rows {
+		amount := entities.CurrencySymbol(income.Currency) + "%.2f"
+
+		row := []string{income.Ticker}
+		for _, value := range income.Amounts {
+			row = append(row, showIncome(amount, value))
+		}
+
+		rows = append(rows, append(row, fmt.Sprintf(amount, income.Total())))
+	}
+
+	total := []string{"Total"}
+	if currency, sameCurrency := calendar.Currency(); sameCurrency {
+		amount := entities.CurrencySymbol(currency) + "%.2f"
+
+		for month := range calendar.Months {
+			total = append(total, fmt.Sprintf(amount, calendar.MonthTotal(month)))
+		}
+
+		total = append(total, fmt.Sprintf(amount, calendar.Total()))
+	} else {
+		for range len(calendar.Months) + 1 {
+			total = append(total, "-")
+		}
+	}
+
+	if err := table.Bulk(append(rows, total)); err != nil {
+		return fmt.Errorf("failed to append income calendar rows: %w", err)
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
+
+// showIncome formats the income of a month, using a dash for the months without any payment.
+func showIncome(format string, value float64) string {
+	if value == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf(format, value)
+}
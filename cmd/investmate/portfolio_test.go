@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortfolio_Resolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read the holdings file of the config file when the flag is not given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &portfolioOptions{
+			globalOptions: &globalOptions{config: &config.Config{Holdings: config.Holdings{Path: "holdings.csv"}}},
+		}
+		command := newPortfolioCommand(options.globalOptions)
+
+		// when
+		err := options.resolve(command)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "holdings.csv", options.holdings)
+	})
+
+	t.Run("should return an error when no holdings file is given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("years: 3\n"), 0o600))
+
+		command := newRootCommand()
+		command.SetArgs([]string{"portfolio", "--config", path})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.ErrorIs(t, err, errMissingHoldings)
+	})
+}
+
+func TestPortfolio_Calendar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the income calendar of the holdings when the calendar flag is given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		firstOfMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC)
+		content, err := json.Marshal(map[string]any{
+			"fetched_at": time.Now(),
+			"records": []entities.Dividend{
+				{PaymentDate: firstOfMonth.AddDate(0, -2, 4), Amount: 0.5},
+				{PaymentDate: firstOfMonth.AddDate(0, -1, 4), Amount: 0.5},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(directory, "cache", "dividends", providerNasdaq), 0o755))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(directory, "cache", "dividends", providerNasdaq, "JEPI.json"), content, 0o600,
+		))
+
+		holdings := filepath.Join(directory, "holdings.csv")
+		require.NoError(t, os.WriteFile(holdings, []byte("ticker,shares,cost_basis\nJEPI,30,1500\n"), 0o600))
+
+		configPath := filepath.Join(directory, "config.yaml")
+		require.NoError(t, os.WriteFile(
+			configPath, []byte("cache:\n  directory: "+filepath.Join(directory, "cache")+"\n"), 0o600,
+		))
+
+		var buffer bytes.Buffer
+
+		command := newRootCommand()
+		command.SetOut(&buffer)
+		command.SetArgs([]string{
+			"portfolio", "--config", configPath, "--holdings", holdings, "--offline", "--calendar",
+		})
+
+		// when
+		err = command.Execute()
+
+		// then
+		require.ErrorIs(t, err, cache.ErrNotCached)
+		assert.Contains(t, buffer.String(), strings.ToUpper(firstOfMonth.Format("Jan 2006")))
+		assert.Contains(t, buffer.String(), "$15.00")
+		assert.Contains(t, buffer.String(), "$180.00")
+	})
+}
+
+func TestPortfolio_RenderPortfolio(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render every position with its weight and the total of the portfolio", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		schd := entities.NewETF("SCHD")
+		schd.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2025, time.March, 26, 0, 0, 0, 0, time.UTC), Amount: 0.25},
+			{PaymentDate: time.Date(2025, time.June, 25, 0, 0, 0, 0, time.UTC), Amount: 0.25},
+		})
+		schd.SetPriceBars([]entities.PriceBar{{Date: time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC), Close: 25}})
+
+		portfolio := entities.NewPortfolio([]entities.Holding{
+			{Ticker: "SCHD", Account: "IRA", Shares: 100, CostBasis: 2000},
+			{Ticker: "JEPI", Shares: 10, CostBasis: 500},
+		}, []*entities.ETF{schd})
+
+		// when
+		err := renderPortfolio(&buffer, portfolio)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "$2500.00")
+		assert.Contains(t, buffer.String(), "100.00%")
+		assert.Contains(t, buffer.String(), "$8.33")
+		assert.Contains(t, buffer.String(), "IRA")
+		assert.Contains(t, buffer.String(), "4.00%")
+	})
+
+	t.Run("should not add up the positions in different currencies", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+		portfolio := entities.NewPortfolio([]entities.Holding{
+			{Ticker: "SCHD", Shares: 10, CostBasis: 250},
+			{Ticker: "MXRF11", Shares: 100, CostBasis: 1000},
+		}, nil)
+
+		// when
+		err := renderPortfolio(&buffer, portfolio)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "R$1000.00")
+		assert.NotContains(t, buffer.String(), "$1250.00")
+	})
+}
+
+func TestPortfolio_RenderIncomeCalendar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the income of every ticker in each month and the monthly totals", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		jepi := entities.NewETF("JEPI")
+		jepi.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC), Amount: 0.5},
+			{PaymentDate: time.Date(2025, time.June, 4, 0, 0, 0, 0, time.UTC), Amount: 0.5},
+		})
+
+		portfolio := entities.NewPortfolio([]entities.Holding{{Ticker: "JEPI", Shares: 30}}, []*entities.ETF{jepi})
+		calendar := entities.NewIncomeCalendar(portfolio, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), 12)
+
+		// when
+		err := renderIncomeCalendar(&buffer, calendar)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "JUL 2025")
+		assert.Contains(t, buffer.String(), "JUN 2026")
+		assert.Contains(t, buffer.String(), "$15.00")
+		assert.Contains(t, buffer.String(), "$180.00")
+	})
+}
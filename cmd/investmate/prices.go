@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+// pricesOptions holds the flags of the prices command.
+type pricesOptions struct {
+	*globalOptions
+
+	daily bool
+}
+
+// newPricesCommand creates the command that lists the closing prices of the given tickers.
+func newPricesCommand(global *globalOptions) *cobra.Command {
+	options := &pricesOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "prices TICKER [TICKER...]",
+		Short: "List the yearly average closing prices, or every daily bar, of the given tickers",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			return runPrices(command.Context(), command.OutOrStdout(), args, options)
+		},
+	}
+
+	command.Flags().BoolVar(&options.daily, "daily", false, "list every daily bar instead of the yearly averages")
+
+	return command
+}
+
+// runPrices fetches the price history of every ticker and renders either one row per ticker or one row per day.
+// The tickers that fail are left out of the table and their errors are returned after rendering the others.
+func runPrices(ctx context.Context, writer io.Writer, tickers []string, options *pricesOptions) error {
+	repos, err := newRepositories(options.globalOptions)
+	if err != nil {
+		return err
+	}
+
+	year := currentYear()
+	table := tablewriter.NewWriter(writer)
+
+	if options.daily {
+		table.Header("ETF", "Date", "Open", "High", "Low", "Close", "Volume")
+	} else {
+		table.Header(append(yearHeaders(year, options.years), "Averages"))
+	}
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	etfs, errs := fetchPriceHistories(fetchCtx, tickers, repos, options.globalOptions, false)
+
+	for _, etf := range etfs {
+		if etf == nil {
+			continue
+		}
+
+		name := etf.Name
+
+		var rows [][]string
+		if options.daily {
+			rows = priceBarRows(etf, year-options.years+1)
+		} else {
+			row := []string{name}
+			row = append(row, etf.ShowClosingPricesPerYear(year, options.years)...)
+			row = append(row, etf.FormatAmount(etf.AverageClosingPrices(year, options.years)))
+			rows = [][]string{row}
+		}
+
+		if err = table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to append close price rows for ETF %s: %w", name, err)
+		}
+	}
+
+	if err = table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// fetchPriceHistories fetches the split-adjusted price history of every ticker at the same time, converted into the
+// report currency, along with the dividends when the total returns need them. The tickers whose prices fail are left
+// nil and their errors are returned at the same index, followed by the error of the currency conversion. The tickers
+// whose splits fail keep their unadjusted records, and the ones whose dividends fail keep only their prices.
+func fetchPriceHistories(
+	ctx context.Context,
+	tickers []string,
+	repos *repositorySet,
+	options *globalOptions,
+	withDividends bool,
+) ([]*entities.ETF, []error) {
+	etfs := make([]*entities.ETF, len(tickers))
+	errs := make([]error, len(tickers))
+
+	called := forEachConcurrently(ctx, len(tickers), options.concurrency, func(index int) {
+		name := strings.ToUpper(tickers[index])
+
+		requestCtx, cancelRequest := context.WithTimeout(ctx, options.requestTimeout)
+		defer cancelRequest()
+
+		// The splits come first, since a new split invalidates the cached prices.
+		splits, splitsErr := repos.splits.ListSplitsByETF(requestCtx, name)
+
+		priceBars, listErr := repos.prices.ListPriceBarsByETF(requestCtx, name)
+		if listErr != nil {
+			errs[index] = fmt.Errorf("failed to fetch closing prices for ETF %s: %w", name, listErr)
+			return
+		}
+
+		etfs[index] = entities.NewETF(name)
+		etfs[index].SetPriceBars(priceBars)
+
+		var tickerErrs []error
+		if withDividends {
+			dividends, dividendsErr := repos.dividends.ListDividendsByETF(requestCtx, name)
+			if dividendsErr != nil {
+				tickerErrs = append(tickerErrs, fmt.Errorf(
+					"failed to fetch dividends for ETF %s, left out of the returns: %w", name, dividendsErr,
+				))
+			}
+
+			etfs[index].SetDividends(dividends)
+		}
+
+		// Without the splits the prices are still shown, just not adjusted, like in processETF.
+		if splitsErr != nil {
+			tickerErrs = append(tickerErrs, fmt.Errorf(
+				"failed to fetch splits for ETF %s, left unadjusted: %w", name, splitsErr,
+			))
+		}
+
+		etfs[index].AdjustForSplits(splits)
+		errs[index] = errors.Join(tickerErrs...)
+	})
+
+	for index, wasCalled := range called {
+		if !wasCalled {
+			errs[index] = fmt.Errorf("%w: %s: %w", errSkipped, tickers[index], context.Cause(ctx))
+		}
+	}
+
+	if err := convertCurrencies(ctx, etfs, options); err != nil {
+		errs = append(errs, err)
+	}
+
+	return etfs, errs
+}
+
+// priceBarRows builds one row per trading day since the first year.
+func priceBarRows(etf *entities.ETF, firstYear int) [][]string {
+	var rows [][]string
+
+	for _, bar := range etf.PriceBars {
+		if bar.Date.Year() < firstYear {
+			continue
+		}
+
+		rows = append(rows, []string{
+			etf.Name,
+			bar.Date.Format(time.DateOnly),
+			etf.FormatAmount(bar.Open),
+			etf.FormatAmount(bar.High),
+			etf.FormatAmount(bar.Low),
+			etf.FormatAmount(bar.Close),
+			fmt.Sprintf("%.0f", bar.Volume),
+		})
+	}
+
+	return rows
+}
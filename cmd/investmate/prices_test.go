@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrices_FetchPriceHistories(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep the unadjusted prices when the splits cannot be fetched", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repos := &repositorySet{
+			prices: &stubPricesRepository{data: []entities.PriceBar{
+				{Date: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), Close: 100},
+			}},
+			splits: &stubSplitsRepository{err: errors.New("network error")},
+		}
+		options := &globalOptions{years: 1, concurrency: 1, requestTimeout: time.Second, config: &config.Config{}}
+
+		// when
+		etfs, errs := fetchPriceHistories(context.Background(), []string{"SPY"}, repos, options, false)
+
+		// then
+		require.Len(t, etfs, 1)
+		require.NotNil(t, etfs[0])
+		assert.Len(t, etfs[0].PriceBars, 1)
+		assert.ErrorContains(t, errors.Join(errs...), "left unadjusted")
+	})
+
+	t.Run("should add the dividends adjusted for the splits when the total returns need them", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repos := &repositorySet{
+			dividends: &stubDividendsRepository{data: []entities.Dividend{
+				{ExDate: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC), Amount: 1},
+			}},
+			prices: &stubPricesRepository{data: []entities.PriceBar{
+				{Date: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), Close: 100},
+			}},
+			splits: &stubSplitsRepository{data: []entities.Split{
+				{Date: time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), Numerator: 2, Denominator: 1},
+			}},
+		}
+		options := &globalOptions{years: 1, concurrency: 1, requestTimeout: time.Second, config: &config.Config{}}
+
+		// when
+		etfs, errs := fetchPriceHistories(context.Background(), []string{"SPY"}, repos, options, true)
+
+		// then
+		require.NoError(t, errors.Join(errs...))
+		require.Len(t, etfs[0].Dividends, 1)
+		assert.InDelta(t, 0.5, etfs[0].Dividends[0].Amount, 0.001)
+		assert.InDelta(t, 50, etfs[0].PriceBars[0].Close, 0.001)
+	})
+}
+
+func TestPrices_OfflineCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the local CSV prices offline without asking Yahoo for the splits", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(directory, "SPY.csv"),
+			[]byte("Date,Open,High,Low,Close,Volume\n"+time.Now().Format(time.DateOnly)+",470,475,469,472.65,1000\n"),
+			0o600,
+		))
+
+		configPath := filepath.Join(directory, "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(
+			"cache:\n  directory: "+filepath.Join(directory, "cache")+"\n"+
+				"csv:\n  source: "+filepath.Join(directory, "{ticker}.csv")+"\n",
+		), 0o600))
+
+		var buffer bytes.Buffer
+
+		command := newRootCommand()
+		command.SetOut(&buffer)
+		command.SetArgs([]string{"prices", "SPY", "--config", configPath, "--provider", "csv", "--offline"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "472.65")
+	})
+}
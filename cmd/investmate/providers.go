@@ -0,0 +1,308 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/cache"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/csvprices"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/fallback"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/historyorg"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/nasdaq"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/statusinvest"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/yahoo"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// providerNasdaq selects the Nasdaq REST API repositories.
+	providerNasdaq = "nasdaq"
+
+	// providerStatusInvest selects Status Invest, which offers the dividends and prices of US ETFs and B3 funds.
+	providerStatusInvest = "statusinvest"
+
+	// providerHistoryOrg selects the dividendhistory.org crawler, which only offers dividends.
+	providerHistoryOrg = "historyorg"
+
+	// providerYahoo selects the Yahoo Finance chart API, which offers dividends and prices.
+	providerYahoo = "yahoo"
+
+	// providerCSV selects the CSV price history configured in the csv section, which only offers prices.
+	providerCSV = "csv"
+
+	// defaultDividendsTTL is how long the current year of the cached dividends is served without refetching.
+	defaultDividendsTTL = 24 * time.Hour
+
+	// defaultPricesTTL is how long the current year of the cached prices is served without refetching.
+	defaultPricesTTL = 12 * time.Hour
+
+	// defaultFundamentalsTTL is how long the cached fundamentals are served without refetching.
+	defaultFundamentalsTTL = 7 * 24 * time.Hour
+)
+
+var (
+	// errUnknownProvider is returned when the configured data provider is not supported.
+	errUnknownProvider = errors.New("unknown data provider")
+
+	// errMissingCSVSource is returned when the CSV provider is selected without a source in the config file.
+	errMissingCSVSource = errors.New("the csv provider requires the csv.source setting")
+)
+
+// localRepository is implemented by the repositories that may read local files, which are never cached so they
+// keep working in offline mode.
+type localRepository interface {
+	IsLocal() bool
+}
+
+// repositorySet groups the repositories a provider offers to the commands.
+// The kinds of data a single provider does not offer are left nil.
+type repositorySet struct {
+	dividends    repositories.DividendsRepository
+	prices       repositories.PricesRepository
+	fundamentals repositories.FundamentalsRepository
+	splits       repositories.SplitsRepository
+}
+
+// providerChains lists the repositories of every selected provider, per kind of data, in the configured order.
+type providerChains struct {
+	dividends    []fallback.Provider[repositories.DividendsRepository]
+	prices       []fallback.Provider[repositories.PricesRepository]
+	fundamentals []fallback.Provider[repositories.FundamentalsRepository]
+	splits       []fallback.Provider[repositories.SplitsRepository]
+}
+
+// newRepositories chains the repositories of the selected data providers in the configured order. Every kind of
+// data falls back to the next provider that offers it when the previous ones fail or miss some of the years.
+func newRepositories(options *globalOptions) (*repositorySet, error) {
+	chains, err := newProviderChains(options, options.providers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositorySet{
+		dividends:    fallback.NewFallbackDividendsRepository(chains.dividends, options.years),
+		prices:       fallback.NewFallbackPricesRepository(chains.prices, options.years),
+		fundamentals: fallback.NewFallbackFundamentalsRepository(chains.fundamentals),
+		splits:       fallback.NewFallbackSplitsRepository(chains.splits),
+	}, nil
+}
+
+// newProviderChains creates the repositories of the named providers, each one decorated with the on-disk cache
+// unless it is disabled. Every price history needs the splits, so Yahoo supplies them when no named provider does,
+// unless every named provider reads local files.
+func newProviderChains(options *globalOptions, names []string) (*providerChains, error) {
+	var store *cache.Store
+
+	if !options.noCache {
+		var err error
+		if store, err = newStore(options); err != nil {
+			return nil, err
+		}
+	}
+
+	chains := &providerChains{}
+	remote := false
+
+	for _, name := range names {
+		repos, err := providerRepositories(name, options)
+		if err != nil {
+			return nil, err
+		}
+
+		remote = remote || !isLocal(repos)
+
+		if store != nil {
+			repos = withCache(name, repos, store, options)
+		}
+
+		chains.add(name, repos)
+	}
+
+	// The local files must keep working without the network, so they are never made to depend on Yahoo.
+	if len(chains.splits) == 0 && !remote {
+		logger.Warn("No selected provider offers the splits, the prices and dividends are left unadjusted")
+	}
+
+	if len(chains.splits) == 0 && remote {
+		repos := &repositorySet{splits: yahoo.NewChartSplitsRepository(yahoo.NewClient(yahoo.DefaultBaseURL, nil))}
+		if store != nil {
+			repos = withCache(providerYahoo, repos, store, options)
+		}
+
+		chains.add(providerYahoo, repos)
+	}
+
+	return chains, nil
+}
+
+// isLocal reports whether every repository the provider offers reads local files.
+func isLocal(repos *repositorySet) bool {
+	for _, repository := range []any{repos.dividends, repos.prices, repos.fundamentals, repos.splits} {
+		if repository == nil {
+			continue
+		}
+
+		if local, ok := repository.(localRepository); !ok || !local.IsLocal() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// add appends every repository the provider offers to the chain of its kind of data.
+func (c *providerChains) add(name string, repos *repositorySet) {
+	if repos.dividends != nil {
+		c.dividends = append(c.dividends, fallback.Provider[repositories.DividendsRepository]{
+			Name: name, Repository: repos.dividends,
+		})
+	}
+
+	if repos.prices != nil {
+		c.prices = append(c.prices, fallback.Provider[repositories.PricesRepository]{
+			Name: name, Repository: repos.prices,
+		})
+	}
+
+	if repos.fundamentals != nil {
+		c.fundamentals = append(c.fundamentals, fallback.Provider[repositories.FundamentalsRepository]{
+			Name: name, Repository: repos.fundamentals,
+		})
+	}
+
+	if repos.splits != nil {
+		c.splits = append(c.splits, fallback.Provider[repositories.SplitsRepository]{
+			Name: name, Repository: repos.splits,
+		})
+	}
+}
+
+// providerRepositories creates the repositories a single data provider offers.
+func providerRepositories(name string, options *globalOptions) (*repositorySet, error) {
+	switch name {
+	case providerNasdaq:
+		// A single client is shared so the rate limit covers every concurrent fetch.
+		client := nasdaq.NewClient(nasdaq.ClientOptions{})
+
+		return &repositorySet{
+			dividends:    nasdaq.NewAPIDividendsRepository(client),
+			prices:       nasdaq.NewAPIPricesRepository(client, options.years),
+			fundamentals: nasdaq.NewAPIFundamentalsRepository(client),
+		}, nil
+	case providerStatusInvest:
+		return &repositorySet{
+			dividends: statusinvest.NewCrawlerDividendsRepository(),
+			prices:    statusinvest.NewAPIPricesRepository(options.years),
+		}, nil
+	case providerHistoryOrg:
+		return &repositorySet{dividends: historyorg.NewCrawlerDividendsRepository()}, nil
+	case providerYahoo:
+		client := yahoo.NewClient(yahoo.DefaultBaseURL, nil)
+
+		return &repositorySet{
+			dividends: yahoo.NewChartDividendsRepository(client),
+			prices:    yahoo.NewChartPricesRepository(client, options.years),
+			splits:    yahoo.NewChartSplitsRepository(client),
+		}, nil
+	case providerCSV:
+		return newCSVRepositories(options)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProvider, name)
+	}
+}
+
+// newCSVRepositories creates the CSV prices repository from the csv section of the config file.
+func newCSVRepositories(options *globalOptions) (*repositorySet, error) {
+	settings := options.config.CSV
+	if settings.Source == "" {
+		return nil, errMissingCSVSource
+	}
+
+	return &repositorySet{
+		prices: csvprices.NewCSVPricesRepository(csvprices.Options{
+			Source:        settings.Source,
+			DateFormat:    settings.DateFormat,
+			Delimiter:     delimiterOf(settings.Delimiter),
+			DecimalComma:  settings.DecimalComma,
+			Columns:       csvprices.Columns(settings.Columns),
+			SplitAdjusted: settings.SplitAdjusted,
+		}, options.years),
+	}, nil
+}
+
+// delimiterOf returns the first character of the configured delimiter, or zero for the default one.
+func delimiterOf(setting string) rune {
+	delimiter, _ := utf8.DecodeRuneInString(setting)
+	if delimiter == utf8.RuneError {
+		return 0
+	}
+
+	return delimiter
+}
+
+// newStore opens the on-disk cache in the configured directory, or in the user cache directory.
+func newStore(options *globalOptions) (*cache.Store, error) {
+	directory := options.config.Cache.Directory
+	if directory == "" {
+		var err error
+		if directory, err = cache.DefaultDirectory(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cache.NewStore(directory), nil
+}
+
+// withCache decorates every repository the provider offers with the on-disk cache, in the provider's namespace.
+func withCache(name string, repos *repositorySet, store *cache.Store, options *globalOptions) *repositorySet {
+	settings := options.config.Cache
+	cached := &repositorySet{}
+
+	if repos.dividends != nil {
+		cached.dividends = cache.NewCachedDividendsRepository(
+			repos.dividends, store, name, durationOr(settings.DividendsTTL, defaultDividendsTTL), options.offline,
+		)
+	}
+
+	if local, ok := repos.prices.(localRepository); ok && local.IsLocal() {
+		cached.prices = repos.prices
+	} else if repos.prices != nil {
+		// The prices are fetched for a window of years, so each window is cached on its own.
+		namespace := fmt.Sprintf("%s-%dy", name, options.years)
+		cached.prices = cache.NewCachedPricesRepository(
+			repos.prices, store, namespace, durationOr(settings.PricesTTL, defaultPricesTTL), options.offline,
+		)
+	}
+
+	if repos.fundamentals != nil {
+		cached.fundamentals = cache.NewCachedFundamentalsRepository(
+			repos.fundamentals, store, name, durationOr(settings.FundamentalsTTL, defaultFundamentalsTTL), options.offline,
+		)
+	}
+
+	if repos.splits != nil {
+		// The splits come with the dividend events, so they are refreshed as often.
+		cached.splits = cache.NewCachedSplitsRepository(
+			repos.splits, store, name, durationOr(settings.DividendsTTL, defaultDividendsTTL), options.offline,
+		)
+	}
+
+	return cached
+}
+
+// dividendsProviders lists every provider that offers dividends.
+func dividendsProviders() []string {
+	return []string{providerNasdaq, providerYahoo, providerStatusInvest, providerHistoryOrg}
+}
+
+// durationOr returns the configured duration, or the fallback when it is not set.
+func durationOr(configured, fallback time.Duration) time.Duration {
+	if configured == 0 {
+		return fallback
+	}
+
+	return configured
+}
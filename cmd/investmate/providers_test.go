@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/csvprices"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviders_NewProviderChains(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not cache the prices read from local CSV files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{
+			years:   defaultYearsToFetch,
+			offline: true,
+			config: &config.Config{
+				Cache: config.Cache{Directory: t.TempDir()},
+				CSV:   config.CSVPrices{Source: "/data/{ticker}.csv"},
+			},
+		}
+
+		// when
+		chains, err := newProviderChains(options, []string{providerCSV})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, chains.prices, 1)
+		assert.IsType(t, &csvprices.CSVPricesRepository{}, chains.prices[0].Repository)
+		assert.Empty(t, chains.dividends)
+	})
+
+	t.Run("should require the source of the CSV provider", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{years: defaultYearsToFetch, config: &config.Config{}}
+
+		// when
+		_, err := newProviderChains(options, []string{providerCSV})
+
+		// then
+		require.ErrorIs(t, err, errMissingCSVSource)
+	})
+
+	t.Run("should take the splits from Yahoo when no named provider offers them", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{years: defaultYearsToFetch, noCache: true, config: &config.Config{}}
+
+		// when
+		chains, err := newProviderChains(options, []string{providerNasdaq, providerHistoryOrg})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, chains.splits, 1)
+		assert.Equal(t, providerYahoo, chains.splits[0].Name)
+	})
+
+	t.Run("should not take the splits from Yahoo when every named provider reads local files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{
+			years:   defaultYearsToFetch,
+			noCache: true,
+			config:  &config.Config{CSV: config.CSVPrices{Source: "/data/{ticker}.csv"}},
+		}
+
+		// when
+		chains, err := newProviderChains(options, []string{providerCSV})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, chains.splits)
+	})
+
+	t.Run("should reject an unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &globalOptions{years: defaultYearsToFetch, noCache: true, config: &config.Config{}}
+
+		// when
+		_, err := newProviderChains(options, []string{"unknown"})
+
+		// then
+		require.ErrorIs(t, err, errUnknownProvider)
+	})
+}
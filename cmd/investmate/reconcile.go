@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/fallback"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultTolerancePercentage is the largest spread between the providers' amounts still considered a match.
+	defaultTolerancePercentage = 1
+)
+
+// errDiscrepanciesFound is returned when the providers disagree on any year or payment.
+var errDiscrepanciesFound = errors.New("the providers disagree")
+
+// reconcileOptions holds the flags of the reconcile command.
+type reconcileOptions struct {
+	*globalOptions
+
+	tolerancePercentage float64
+}
+
+// newReconcileCommand creates the command that compares the dividends reported by every provider.
+func newReconcileCommand(global *globalOptions) *cobra.Command {
+	options := &reconcileOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "reconcile TICKER",
+		Short: "Compare the dividends of the ticker across every provider, per year and per payment",
+		Long: "Compare the dividends of the ticker across every provider, per year and per payment.\n" +
+			"Every provider that offers dividends is queried, unless --provider is given explicitly.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			names := dividendsProviders()
+			if command.Flags().Changed("provider") {
+				names = options.providers
+			}
+
+			return runReconcile(command.Context(), command.OutOrStdout(), strings.ToUpper(args[0]), names, options)
+		},
+	}
+
+	command.Flags().Float64Var(
+		&options.tolerancePercentage, "tolerance", defaultTolerancePercentage,
+		"largest percentage difference between the providers' amounts still considered a match",
+	)
+
+	return command
+}
+
+// runReconcile fetches the dividends of the ticker from every provider at the same time, adjusts them for the
+// splits, and renders the yearly sums and the payments side by side. The providers that fail are left out of the
+// comparison and their errors are returned after rendering, and otherwise any discrepancy is reported as an error.
+func runReconcile(
+	ctx context.Context,
+	writer io.Writer,
+	ticker string,
+	names []string,
+	options *reconcileOptions,
+) error {
+	chains, err := newProviderChains(options.globalOptions, names)
+	if err != nil {
+		return err
+	}
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	// Some providers restate the payments in the current share terms and others do not, so every provider is
+	// adjusted for the same splits before they are compared. The splits come first, as in processETF.
+	splitsCtx, cancelSplits := context.WithTimeout(fetchCtx, options.requestTimeout)
+	splits, splitsErr := fallback.NewFallbackSplitsRepository(chains.splits).ListSplitsByETF(splitsCtx, ticker)
+	cancelSplits()
+
+	dividends := make([][]entities.Dividend, len(chains.dividends))
+	errs := make([]error, len(chains.dividends))
+
+	called := forEachConcurrently(fetchCtx, len(chains.dividends), options.concurrency, func(index int) {
+		provider := chains.dividends[index]
+
+		requestCtx, cancelRequest := context.WithTimeout(fetchCtx, options.requestTimeout)
+		defer cancelRequest()
+
+		dividends[index], errs[index] = provider.Repository.ListDividendsByETF(requestCtx, ticker)
+		if errs[index] != nil {
+			errs[index] = fmt.Errorf("failed to fetch dividends for ETF %s from %s: %w", ticker, provider.Name, errs[index])
+		}
+	})
+
+	var providers []string
+
+	byProvider := make(map[string][]entities.Dividend)
+	for index, provider := range chains.dividends {
+		switch {
+		case !called[index]:
+			errs[index] = fmt.Errorf("%w: %s: %w", errSkipped, provider.Name, context.Cause(fetchCtx))
+		case errs[index] == nil:
+			etf := entities.NewETF(ticker)
+			etf.SetDividends(dividends[index])
+			etf.AdjustForSplits(splits)
+
+			providers = append(providers, provider.Name)
+			byProvider[provider.Name] = etf.Dividends
+		}
+	}
+
+	if splitsErr != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch splits for ETF %s, left unadjusted: %w", ticker, splitsErr))
+	}
+
+	reconciliation := entities.ReconcileDividends(providers, byProvider, currentYear()-options.years+1)
+
+	discrepancies, err := renderReconciliation(writer, ticker, reconciliation, options.tolerancePercentage)
+	if err != nil {
+		return err
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if discrepancies > 0 {
+		return fmt.Errorf("%w on %d years or payments of ETF %s", errDiscrepanciesFound, discrepancies, ticker)
+	}
+
+	return nil
+}
+
+// renderReconciliation writes the yearly and the per-payment tables and returns how many rows are discrepant.
+func renderReconciliation(
+	writer io.Writer,
+	ticker string,
+	reconciliation entities.Reconciliation,
+	tolerancePercentage float64,
+) (int, error) {
+	var discrepancies int
+
+	symbol := entities.CurrencySymbol(entities.MarketOf(ticker).Currency())
+
+	yearRows := make([][]string, 0, len(reconciliation.Years))
+	for _, year := range reconciliation.Years {
+		row, discrepant := reconciledRow(
+			year.Year, year.ReconciledAmounts, reconciliation.Providers, symbol, tolerancePercentage,
+		)
+		if discrepant {
+			discrepancies++
+		}
+
+		yearRows = append(yearRows, row)
+	}
+
+	paymentRows := make([][]string, 0, len(reconciliation.Payments))
+	for _, payment := range reconciliation.Payments {
+		row, discrepant := reconciledRow(
+			showDate(payment.Date), payment.ReconciledAmounts, reconciliation.Providers, symbol, tolerancePercentage,
+		)
+		if discrepant {
+			discrepancies++
+		}
+
+		paymentRows = append(paymentRows, row)
+	}
+
+	tables := []struct {
+		label string
+		rows  [][]string
+	}{
+		{ticker + " Year", yearRows},
+		{ticker + " Ex-Date", paymentRows},
+	}
+
+	for _, section := range tables {
+		table := tablewriter.NewWriter(writer)
+
+		headers := append([]string{section.label}, reconciliation.Providers...)
+		table.Header(append(headers, "Spread", "Status"))
+
+		if err := table.Bulk(section.rows); err != nil {
+			return 0, fmt.Errorf("failed to append reconciliation rows for ETF %s: %w", ticker, err)
+		}
+
+		if err := table.Render(); err != nil {
+			return 0, fmt.Errorf("failed to render the table: %w", err)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// reconciledRow builds the row of a year or payment with the amount of every provider, the spread between them
+// and whether they match, reporting whether the row is discrepant.
+func reconciledRow(
+	label string,
+	reconciled entities.ReconciledAmounts,
+	providers []string,
+	symbol string,
+	tolerancePercentage float64,
+) ([]string, bool) {
+	row := []string{label}
+
+	for _, provider := range providers {
+		if amount, exists := reconciled.Amounts[provider]; exists {
+			row = append(row, fmt.Sprintf("%s%.4f", symbol, amount))
+		} else {
+			row = append(row, "-")
+		}
+	}
+
+	status := "OK"
+	if len(reconciled.Missing) > 0 {
+		status = "MISSING: " + strings.Join(reconciled.Missing, ", ")
+	} else if reconciled.IsDiscrepant(tolerancePercentage) {
+		status = "MISMATCH"
+	}
+
+	row = append(row, fmt.Sprintf("%.2f%%", reconciled.SpreadPercentage()), status)
+
+	return row, reconciled.IsDiscrepant(tolerancePercentage)
+}
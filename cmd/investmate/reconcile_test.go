@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile_ReconciledRow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should name the providers that missed the payment", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reconciled := entities.ReconciledAmounts{
+			Amounts: map[string]float64{"nasdaq": 1.2},
+			Missing: []string{"historyorg"},
+		}
+
+		// when
+		row, discrepant := reconciledRow("2024-12-16", reconciled, []string{"nasdaq", "historyorg"}, "$", 1)
+
+		// then
+		assert.Equal(t, []string{"2024-12-16", "$1.2000", "-", "0.00%", "MISSING: historyorg"}, row)
+		assert.True(t, discrepant)
+	})
+
+	t.Run("should accept the amounts within the tolerance", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reconciled := entities.ReconciledAmounts{Amounts: map[string]float64{"nasdaq": 1.000, "historyorg": 0.995}}
+
+		// when
+		row, discrepant := reconciledRow("2024", reconciled, []string{"nasdaq", "historyorg"}, "$", 1)
+
+		// then
+		assert.Equal(t, "OK", row[len(row)-1])
+		assert.False(t, discrepant)
+	})
+}
+
+func TestReconcile_Command(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should adjust every provider for the splits before comparing them", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		directory := t.TempDir()
+		year := time.Now().Year() - 1
+		exDate := time.Date(year, time.March, 3, 0, 0, 0, 0, time.UTC)
+		seed := func(kind, provider string, records any) {
+			content, err := json.Marshal(map[string]any{"fetched_at": time.Now(), "records": records})
+			require.NoError(t, err)
+			require.NoError(t, os.MkdirAll(filepath.Join(directory, "cache", kind, provider), 0o755))
+			require.NoError(t, os.WriteFile(
+				filepath.Join(directory, "cache", kind, provider, "JEPQ.json"), content, 0o600,
+			))
+		}
+		seed("dividends", providerNasdaq, []entities.Dividend{{ExDate: exDate, Amount: 1}})
+		seed("dividends", providerYahoo, []entities.Dividend{{ExDate: exDate, Amount: 0.5, SplitAdjusted: true}})
+		seed("splits", providerYahoo, []entities.Split{
+			{Date: time.Date(year, time.June, 2, 0, 0, 0, 0, time.UTC), Numerator: 2, Denominator: 1},
+		})
+
+		configPath := filepath.Join(directory, "config.yaml")
+		require.NoError(t, os.WriteFile(
+			configPath, []byte("cache:\n  directory: "+filepath.Join(directory, "cache")+"\n"), 0o600,
+		))
+
+		var buffer bytes.Buffer
+
+		command := newRootCommand()
+		command.SetOut(&buffer)
+		command.SetArgs([]string{
+			"reconcile", "JEPQ", "--config", configPath, "--provider", "nasdaq,yahoo", "--offline",
+		})
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "$0.5000")
+		assert.NotContains(t, buffer.String(), "MISMATCH")
+	})
+}
+
+func TestReconcile_RenderReconciliation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the yearly and payment tables and count the discrepant rows", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+		reconciliation := entities.ReconcileDividends(
+			[]string{"nasdaq", "historyorg"},
+			map[string][]entities.Dividend{
+				"nasdaq": {
+					{ExDate: time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), Amount: 0.5},
+					{ExDate: time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC), Amount: 0.5},
+				},
+				"historyorg": {
+					{ExDate: time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), Amount: 0.5},
+					{ExDate: time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC), Amount: 0.6},
+				},
+			},
+			2024,
+		)
+
+		// when
+		discrepancies, err := renderReconciliation(&buffer, "SPY", reconciliation, 1)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, discrepancies)
+		assert.Contains(t, buffer.String(), "MISMATCH")
+		assert.Contains(t, buffer.String(), "2024-06-20")
+	})
+}
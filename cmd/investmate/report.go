@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/renderers"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/cache"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultTargetYieldPercentage is the minimum dividend yield percentage considered a good target.
+	defaultTargetYieldPercentage = 9
+
+	// defaultRiskFreeRatePercentage is the yearly rate the Sharpe and Sortino ratios measure the excess return over.
+	defaultRiskFreeRatePercentage = 0
+)
+
+// defaultTickers is the watchlist used when no tickers are provided.
+func defaultTickers() []string {
+	return []string{
+		"SPY", "QQQ", "SCHD", "YYY", "GLD",
+		"HYGW", "RIET", "SDIV", "SVOL", "XYLD",
+	}
+}
+
+// reportOptions holds the flags of the report command.
+type reportOptions struct {
+	*globalOptions
+
+	tickers               []string
+	targetYieldPercentage float64
+	list                  string
+	format                string
+
+	riskFreeRatePercentage float64
+
+	cutThresholdPercentage float64
+	minimumGrowthStreak    int
+	minimumDividendCAGR    float64
+	screenDividendCAGR     bool
+	noCuts                 bool
+}
+
+// resolve applies the settings of the selected watchlist, or the top-level configuration when no list is
+// selected, to the flags that were not set explicitly.
+func (o *reportOptions) resolve(command *cobra.Command) error {
+	flags := command.Flags()
+	o.screenDividendCAGR = flags.Changed("min-dividend-cagr")
+
+	if !flags.Changed("risk-free-rate") && o.config.RiskFreeRatePercentage != 0 {
+		o.riskFreeRatePercentage = o.config.RiskFreeRatePercentage
+	}
+
+	if o.list == "" {
+		if !flags.Changed("target-yield") && o.config.TargetYieldPercentage != 0 {
+			o.targetYieldPercentage = o.config.TargetYieldPercentage
+		}
+
+		return nil
+	}
+
+	watchlist, err := o.config.Watchlist(o.list)
+	if err != nil {
+		return err
+	}
+
+	if !flags.Changed("tickers") && len(watchlist.Tickers) > 0 {
+		o.tickers = watchlist.Tickers
+	}
+
+	if !flags.Changed("target-yield") && watchlist.TargetYieldPercentage != 0 {
+		o.targetYieldPercentage = watchlist.TargetYieldPercentage
+	}
+
+	if !flags.Changed("years") && watchlist.Years != 0 {
+		o.years = watchlist.Years
+	}
+
+	if !flags.Changed("provider") && len(watchlist.Provider) > 0 {
+		o.providers = watchlist.Provider
+	}
+
+	return o.validate()
+}
+
+// newReportCommand creates the command that renders the full dividend, price and yield report.
+func newReportCommand(global *globalOptions) *cobra.Command {
+	options := &reportOptions{globalOptions: global}
+
+	command := &cobra.Command{
+		Use:   "report",
+		Short: "Render the dividends, closing prices, yields and fundamentals of the watchlist",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, _ []string) error {
+			if err := options.resolve(command); err != nil {
+				return err
+			}
+
+			return runReport(command.Context(), command.OutOrStdout(), options)
+		},
+	}
+
+	command.Flags().StringSliceVar(&options.tickers, "tickers", defaultTickers(), "comma-separated list of tickers")
+	command.Flags().Float64Var(
+		&options.targetYieldPercentage, "target-yield", defaultTargetYieldPercentage,
+		"minimum dividend yield percentage considered a good target",
+	)
+	command.Flags().StringVar(&options.list, "list", "", "name of the watchlist defined in the config file")
+	command.Flags().StringVar(
+		&options.format, "format", renderers.FormatTable,
+		"output format, one of: "+strings.Join(renderers.Formats(), ", "),
+	)
+	command.Flags().Float64Var(
+		&options.riskFreeRatePercentage, "risk-free-rate", defaultRiskFreeRatePercentage,
+		"yearly risk-free rate percentage the Sharpe and Sortino ratios measure the excess return over",
+	)
+	command.Flags().Float64Var(
+		&options.cutThresholdPercentage, "cut-threshold", entities.DefaultCutThresholdPercentage,
+		"smallest drop of the yearly dividends, in percent, considered a dividend cut",
+	)
+	command.Flags().IntVar(
+		&options.minimumGrowthStreak, "min-growth-streak", 0,
+		"leave out the ETFs with fewer consecutive years of dividend increase",
+	)
+	command.Flags().Float64Var(
+		&options.minimumDividendCAGR, "min-dividend-cagr", 0,
+		"leave out the ETFs whose dividend CAGR percentage over --years is lower",
+	)
+	command.Flags().BoolVar(
+		&options.noCuts, "no-cuts", false, "leave out the ETFs that cut their dividends within --years",
+	)
+
+	return command
+}
+
+// runReport fetches every ticker and renders the report in the selected output format.
+// When the context is canceled or its deadline expires, the data gathered so far is still rendered.
+func runReport(ctx context.Context, writer io.Writer, options *reportOptions) error {
+	logger.Info("Starting ETF data scraping...")
+
+	renderer, err := renderers.NewRenderer(options.format)
+	if err != nil {
+		return err
+	}
+
+	repos, err := newRepositories(options.globalOptions)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(options.tickers))
+	for i, ticker := range options.tickers {
+		names[i] = strings.ToUpper(ticker)
+	}
+
+	fetchCtx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	var missing []string
+
+	etfs := make([]*entities.ETF, 0, len(names))
+	for _, result := range fetchETFs(fetchCtx, names, repos, options.concurrency, options.requestTimeout) {
+		if errors.Is(result.err, cache.ErrNotCached) {
+			missing = append(missing, result.etf.Name)
+		}
+
+		if result.err != nil {
+			logger.WithError(result.err).Errorf("Failed to fetch data for ETF: %s", result.etf.Name)
+		}
+
+		etfs = append(etfs, result.etf)
+	}
+
+	if len(missing) > 0 {
+		logger.Warnf("Missing from the cache, run without --offline to fetch them: %s", strings.Join(missing, ", "))
+	}
+
+	if err = convertCurrencies(fetchCtx, etfs, options.globalOptions); err != nil {
+		logger.WithError(err).Error("Failed to convert the report currency")
+	}
+
+	now := time.Now()
+	etfs = screenDividendGrowth(etfs, now.Year(), options.dividendGrowthScreen())
+
+	logger.Info("Rendering the results...")
+
+	err = renderer.Render(writer, renderers.Report{
+		ETFs:                   etfs,
+		Now:                    now,
+		CurrentYear:            now.Year(),
+		TotalYears:             options.years,
+		TargetYieldPercentage:  options.targetYieldPercentage,
+		ShowSources:            len(options.providers) > 1,
+		CutThresholdPercentage: options.cutThresholdPercentage,
+		RiskFreeRatePercentage: options.riskFreeRatePercentage,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Report the interruption or the expired deadline only after the partial results were rendered.
+	return context.Cause(fetchCtx)
+}
+
+// dividendGrowthScreen builds the dividend growth screen of the flags, over the years of the report.
+func (o *reportOptions) dividendGrowthScreen() entities.DividendGrowthScreen {
+	screen := entities.DividendGrowthScreen{
+		MinimumStreak:          o.minimumGrowthStreak,
+		CutThresholdPercentage: o.cutThresholdPercentage,
+	}
+
+	if o.screenDividendCAGR {
+		screen.CAGRYears, screen.MinimumCAGRPercentage = o.years, o.minimumDividendCAGR
+	}
+
+	if o.noCuts {
+		screen.NoCutsYears = o.years
+	}
+
+	return screen
+}
+
+// screenDividendGrowth leaves out the ETFs that do not meet the dividend growth screen, logging their names.
+func screenDividendGrowth(
+	etfs []*entities.ETF,
+	currentYear int,
+	screen entities.DividendGrowthScreen,
+) []*entities.ETF {
+	var screenedOut []string
+
+	kept := make([]*entities.ETF, 0, len(etfs))
+	for _, etf := range etfs {
+		if etf.MeetsDividendGrowthScreen(currentYear, screen) {
+			kept = append(kept, etf)
+		} else {
+			screenedOut = append(screenedOut, etf.Name)
+		}
+	}
+
+	if len(screenedOut) > 0 {
+		logger.Infof("Left out by the dividend growth screen: %s", strings.Join(screenedOut, ", "))
+	}
+
+	return kept
+}
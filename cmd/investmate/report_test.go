@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport_Resolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should apply the watchlist settings to the flags that were not set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &reportOptions{
+			globalOptions: &globalOptions{
+				years:          defaultYearsToFetch,
+				providers:      []string{providerNasdaq},
+				concurrency:    defaultConcurrency,
+				timeout:        defaultTimeout,
+				requestTimeout: defaultRequestTimeout,
+				config: &config.Config{
+					Watchlists: map[string]config.Watchlist{
+						"covered-call": {Tickers: []string{"SVOL", "XYLD"}, TargetYieldPercentage: 12, Years: 3},
+					},
+				},
+			},
+			tickers:               defaultTickers(),
+			targetYieldPercentage: 7,
+			list:                  "covered-call",
+		}
+		command := newReportCommand(options.globalOptions)
+		require.NoError(t, command.ParseFlags([]string{"--target-yield", "7"}))
+
+		// when
+		err := options.resolve(command)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SVOL", "XYLD"}, options.tickers)
+		assert.InDelta(t, 7.0, options.targetYieldPercentage, 0.001)
+		assert.Equal(t, 3, options.years)
+	})
+
+	t.Run("should apply the risk-free rate of the config file when the flag was not set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		options := &reportOptions{
+			globalOptions: &globalOptions{
+				years:     defaultYearsToFetch,
+				providers: []string{providerNasdaq},
+				config:    &config.Config{RiskFreeRatePercentage: 4.5},
+			},
+			tickers: defaultTickers(),
+		}
+		command := newReportCommand(options.globalOptions)
+		require.NoError(t, command.ParseFlags(nil))
+
+		// when
+		err := options.resolve(command)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, 4.5, options.riskFreeRatePercentage, 0.001)
+	})
+
+	t.Run("should return an error when the watchlist is not defined", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("watchlists:\n  gold:\n    tickers: [GLD]\n"), 0o600))
+
+		command := newRootCommand()
+		command.SetArgs([]string{"report", "--config", path, "--list", "covered-call"})
+
+		// when
+		err := command.Execute()
+
+		// then
+		assert.ErrorIs(t, err, config.ErrWatchlistNotFound)
+	})
+}
+
+func TestReport_ScreenDividendGrowth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should leave out the ETFs that cut their dividends within the years of the report", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		grower, cutter := entities.NewETF("SCHD"), entities.NewETF("SDIV")
+		for index, etf := range []*entities.ETF{grower, cutter} {
+			etf.SetDividends([]entities.Dividend{
+				{PaymentDate: time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+				{PaymentDate: time.Date(2023, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+				{PaymentDate: time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 1.2 - float64(index)*0.6},
+			})
+		}
+		options := &reportOptions{
+			globalOptions:          &globalOptions{years: defaultYearsToFetch},
+			cutThresholdPercentage: entities.DefaultCutThresholdPercentage,
+			noCuts:                 true,
+		}
+
+		// when
+		result := screenDividendGrowth([]*entities.ETF{grower, cutter}, 2025, options.dividendGrowthScreen())
+
+		// then
+		assert.Equal(t, []*entities.ETF{grower}, result)
+	})
+}
@@ -0,0 +1,145 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MinimumCorrelationObservations is the fewest daily returns on common dates a correlation is calculated from.
+const MinimumCorrelationObservations = 20
+
+// Correlation holds the Pearson correlation of the daily total returns of two ETFs on their common dates.
+type Correlation struct {
+	Coefficient  float64
+	Observations int // Daily returns on the common dates, zero when the correlation is not available.
+}
+
+// Exists reports whether there were enough common dates to calculate the correlation.
+func (c Correlation) Exists() bool {
+	return c.Observations > 0
+}
+
+// CorrelatedPair names two ETFs whose daily returns move together.
+type CorrelatedPair struct {
+	First       string
+	Second      string
+	Correlation Correlation
+}
+
+// CorrelationMatrix holds the correlation of every pair of ETFs, in the order of their names.
+type CorrelationMatrix struct {
+	Names        []string
+	Correlations [][]Correlation // Indexed by the positions of both ETFs in the names.
+}
+
+// CorrelationBetween aligns the closes of both ETFs on the dates they both traded between the start and the end,
+// and correlates the total returns from each common date to the next. The distributions are added back on their
+// ex-dates, so the price drop of a high-yield fund on its ex-date does not read as a loss.
+func CorrelationBetween(first, second *ETF, start, end time.Time) (Correlation, bool) {
+	closes := make(map[time.Time]PriceBar)
+	for _, bar := range first.barsBetween(start, end) {
+		closes[dayOf(bar.Date)] = bar
+	}
+
+	var firstReturns, secondReturns []float64
+
+	var previousFirst, previousSecond PriceBar
+
+	for _, bar := range second.barsBetween(start, end) {
+		matching, exists := closes[dayOf(bar.Date)]
+		if !exists {
+			continue
+		}
+
+		if previousFirst.Close != 0 && previousSecond.Close != 0 {
+			firstReturns = append(firstReturns, first.totalReturnBetween(previousFirst, matching))
+			secondReturns = append(secondReturns, second.totalReturnBetween(previousSecond, bar))
+		}
+
+		previousFirst, previousSecond = matching, bar
+	}
+
+	if len(firstReturns) < MinimumCorrelationObservations {
+		return Correlation{}, false
+	}
+
+	coefficient, exists := pearson(firstReturns, secondReturns)
+	if !exists {
+		return Correlation{}, false
+	}
+
+	return Correlation{Coefficient: coefficient, Observations: len(firstReturns)}, true
+}
+
+// NewCorrelationMatrix correlates every pair of ETFs between the start and the end.
+func NewCorrelationMatrix(etfs []*ETF, start, end time.Time) CorrelationMatrix {
+	matrix := CorrelationMatrix{
+		Names:        make([]string, len(etfs)),
+		Correlations: make([][]Correlation, len(etfs)),
+	}
+
+	for i, etf := range etfs {
+		matrix.Names[i] = etf.Name
+		matrix.Correlations[i] = make([]Correlation, len(etfs))
+	}
+
+	for i := range etfs {
+		for j := i; j < len(etfs); j++ {
+			correlation, _ := CorrelationBetween(etfs[i], etfs[j], start, end)
+			matrix.Correlations[i][j], matrix.Correlations[j][i] = correlation, correlation
+		}
+	}
+
+	return matrix
+}
+
+// PairsAbove lists the pairs of different ETFs correlated at or above the threshold, the most correlated first.
+func (m CorrelationMatrix) PairsAbove(threshold float64) []CorrelatedPair {
+	var pairs []CorrelatedPair
+
+	for i := range m.Names {
+		for j := i + 1; j < len(m.Names); j++ {
+			if correlation := m.Correlations[i][j]; correlation.Exists() && correlation.Coefficient >= threshold {
+				pairs = append(pairs, CorrelatedPair{First: m.Names[i], Second: m.Names[j], Correlation: correlation})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Correlation.Coefficient > pairs[j].Correlation.Coefficient
+	})
+
+	return pairs
+}
+
+// dayOf truncates the moment to its calendar day, so the closes of two providers match regardless of their time.
+func dayOf(moment time.Time) time.Time {
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// pearson calculates the Pearson correlation coefficient of two series of the same length. It is not available
+// when either series does not vary.
+func pearson(first, second []float64) (float64, bool) {
+	var firstMean, secondMean float64
+	for i := range first {
+		firstMean += first[i]
+		secondMean += second[i]
+	}
+
+	firstMean /= float64(len(first))
+	secondMean /= float64(len(second))
+
+	var covariance, firstVariance, secondVariance float64
+	for i := range first {
+		covariance += (first[i] - firstMean) * (second[i] - secondMean)
+		firstVariance += (first[i] - firstMean) * (first[i] - firstMean)
+		secondVariance += (second[i] - secondMean) * (second[i] - secondMean)
+	}
+
+	if firstVariance == 0 || secondVariance == 0 {
+		return 0, false
+	}
+
+	return covariance / math.Sqrt(firstVariance*secondVariance), true
+}
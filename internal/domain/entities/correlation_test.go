@@ -0,0 +1,103 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type CorrelationTestSuite struct {
+	suite.Suite
+
+	spy, voo, sh *entities.ETF
+	start, end   time.Time
+}
+
+// tradingDays builds one bar per weekday of January 2024, with the closes the function returns for each index.
+func tradingDays(name string, closeOf func(index int) float64) *entities.ETF {
+	var bars []entities.PriceBar
+
+	for day := date(2024, time.January, 1); day.Month() == time.January; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+
+		bars = append(bars, entities.PriceBar{Date: day, Close: closeOf(len(bars))})
+	}
+
+	etf := entities.NewETF(name)
+	etf.SetPriceBars(bars)
+
+	return etf
+}
+
+func (suite *CorrelationTestSuite) SetupTest() {
+	suite.spy = tradingDays("SPY", func(index int) float64 { return 100 + float64(index%5) })
+	suite.voo = tradingDays("VOO", func(index int) float64 { return 2 * (100 + float64(index%5)) })
+	suite.sh = tradingDays("SH", func(index int) float64 { return 50 - float64(index%5) })
+	suite.start, suite.end = date(2024, time.January, 1), date(2024, time.December, 31)
+}
+
+func (suite *CorrelationTestSuite) TestCorrelationBetween() {
+	suite.Run("should fully correlate two funds with the same daily returns", func() {
+		// given
+		// on the setup
+
+		// when
+		result, exists := entities.CorrelationBetween(suite.spy, suite.voo, suite.start, suite.end)
+
+		// then
+		suite.True(exists)
+		suite.InDelta(1.0, result.Coefficient, 0.001)
+		suite.Equal(22, result.Observations)
+	})
+
+	suite.Run("should negatively correlate a fund moving against the other", func() {
+		// given
+		// on the setup
+
+		// when
+		result, exists := entities.CorrelationBetween(suite.spy, suite.sh, suite.start, suite.end)
+
+		// then
+		suite.True(exists)
+		suite.Less(result.Coefficient, -0.9)
+	})
+
+	suite.Run("should not correlate funds with too few common dates", func() {
+		// given
+		recent := entities.NewETF("NEW")
+		recent.SetPriceBars(suite.spy.PriceBars[15:])
+
+		// when
+		_, exists := entities.CorrelationBetween(suite.spy, recent, suite.start, suite.end)
+
+		// then
+		suite.False(exists)
+	})
+}
+
+func (suite *CorrelationTestSuite) TestNewCorrelationMatrix() {
+	suite.Run("should correlate every pair and list the pairs above the threshold", func() {
+		// given
+		etfs := []*entities.ETF{suite.spy, suite.voo, suite.sh}
+
+		// when
+		matrix := entities.NewCorrelationMatrix(etfs, suite.start, suite.end)
+		pairs := matrix.PairsAbove(0.9)
+
+		// then
+		suite.Equal([]string{"SPY", "VOO", "SH"}, matrix.Names)
+		suite.InDelta(1.0, matrix.Correlations[2][2].Coefficient, 0.001)
+		suite.Equal(matrix.Correlations[0][2], matrix.Correlations[2][0])
+		suite.Len(pairs, 1)
+		suite.Equal("SPY", pairs[0].First)
+		suite.Equal("VOO", pairs[0].Second)
+	})
+}
+
+func TestCorrelationTestSuite(t *testing.T) {
+	suite.Run(t, new(CorrelationTestSuite))
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Dividend represents a single distribution paid by an ETF.
+type Dividend struct {
+	ExDate          time.Time
+	RecordDate      time.Time
+	DeclarationDate time.Time
+	PaymentDate     time.Time
+	Amount          float64 // Cash amount paid per share.
+	Source          string  // Name of the provider that supplied the payment, when recorded.
+	SplitAdjusted   bool    // Whether the amount is already in the share terms after every split.
+	Special         bool    // Whether the payment breaks the payout frequency, such as a capital gains distribution.
+}
+
+// Date returns the date used to place the dividend in time: the payment date, or the ex-date when the provider
+// does not report the payment date.
+func (d Dividend) Date() time.Time {
+	if d.PaymentDate.IsZero() {
+		return d.ExDate
+	}
+
+	return d.PaymentDate
+}
+
+// exDateOf returns the ex-date of the dividend, which tells the shares entitled to it, or its payment date when the
+// provider does not report the ex-date.
+func exDateOf(dividend Dividend) time.Time {
+	if dividend.ExDate.IsZero() {
+		return dividend.PaymentDate
+	}
+
+	return dividend.ExDate
+}
+
+// Year returns the year of the dividend date, as used for the yearly keys of the ETF maps.
+func (d Dividend) Year() string {
+	return strconv.Itoa(d.Date().Year())
+}
+
+// SortDividends sorts the dividends in place from the oldest to the most recent.
+func SortDividends(dividends []Dividend) {
+	sort.SliceStable(dividends, func(i, j int) bool {
+		return dividends[i].Date().Before(dividends[j].Date())
+	})
+}
+
+// SumDividendsPerYear aggregates the dividend amounts by the year they were paid.
+func SumDividendsPerYear(dividends []Dividend) map[string]float64 {
+	yearlySums := make(map[string]float64)
+
+	for _, dividend := range dividends {
+		if dividend.Date().IsZero() {
+			continue
+		}
+
+		yearlySums[dividend.Year()] += dividend.Amount
+	}
+
+	return yearlySums
+}
+
+// DividendSourcePerYear returns the providers that supplied the dividends of each year.
+func DividendSourcePerYear(dividends []Dividend) map[string]string {
+	sources := make(map[string][]string)
+
+	for _, dividend := range dividends {
+		if dividend.Source == "" || dividend.Date().IsZero() {
+			continue
+		}
+
+		sources[dividend.Year()] = append(sources[dividend.Year()], dividend.Source)
+	}
+
+	return joinSources(sources)
+}
@@ -0,0 +1,87 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type DividendTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (suite *DividendTestSuite) SetupTest() {
+	suite.etf = &entities.ETF{Name: "TestETF"}
+	suite.etf.SetDividends([]entities.Dividend{
+		{ExDate: date(2024, time.March, 20), PaymentDate: date(2024, time.March, 28), Amount: 0.40},
+		{ExDate: date(2023, time.December, 20), PaymentDate: date(2024, time.January, 3), Amount: 0.50},
+		{ExDate: date(2024, time.June, 20), Amount: 0.44},
+	})
+}
+
+func (suite *DividendTestSuite) TestSetDividends() {
+	suite.Run("should sort the payments by date and aggregate them by year", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.AmountDividendsPerYear
+
+		// then
+		suite.Equal(date(2024, time.January, 3), suite.etf.Dividends[0].PaymentDate)
+		suite.Equal(date(2024, time.June, 20), suite.etf.Dividends[2].ExDate)
+		suite.InDelta(1.34, result["2024"], 0.001)
+		suite.NotContains(result, "2023")
+	})
+}
+
+func (suite *DividendTestSuite) TestNextExDate() {
+	suite.Run("should return the first ex-date after the given moment", func() {
+		// given
+		now := date(2024, time.April, 1)
+
+		// when
+		result, exists := suite.etf.NextExDate(now)
+
+		// then
+		suite.True(exists)
+		suite.Equal(date(2024, time.June, 20), result)
+	})
+
+	suite.Run("should report when no ex-date was announced yet", func() {
+		// given
+		now := date(2024, time.July, 1)
+
+		// when
+		_, exists := suite.etf.NextExDate(now)
+
+		// then
+		suite.False(exists)
+	})
+}
+
+func (suite *DividendTestSuite) TestLastPaymentChangePercentage() {
+	suite.Run("should compare the most recent payment with the previous one", func() {
+		// given
+		// on the setup
+
+		// when
+		result, exists := suite.etf.LastPaymentChangePercentage()
+
+		// then
+		suite.True(exists)
+		suite.InEpsilon(10.0, result, 0.001)
+	})
+}
+
+func TestDividendTestSuite(t *testing.T) {
+	suite.Run(t, new(DividendTestSuite))
+}
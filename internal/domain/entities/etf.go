@@ -3,6 +3,7 @@ package entities
 import (
 	"fmt"
 	"strconv"
+	"time"
 )
 
 // PercentageMultiplier converts a decimal ratio to a percentage value.
@@ -10,10 +11,85 @@ const PercentageMultiplier = 100
 
 // ETF represents an ETF and its dividend cash amounts by year.
 type ETF struct {
+	Fundamentals
+
 	Name                       string
+	Market                     Market
+	Currency                   string             // ISO 4217 code of the prices and the dividends.
+	Dividends                  []Dividend         // Every payment, from the oldest to the most recent.
+	Frequency                  PayoutFrequency    // Detected from the dates of the regular payments.
 	AmountDividendsPerYear     map[string]float64 // Key: Year, Value: Total Dividend Cash.
+	PriceBars                  []PriceBar         // Every trading day, from the oldest to the most recent.
 	AverageClosingPricePerYear map[string]float64 // Key: Year, Value: Average Closing Price.
 	DividendYieldPerYear       map[string]float64 // Key: Year, Value: Dividend Yield Percentage.
+	DividendSourcePerYear      map[string]string  // Key: Year, Value: Provider Name.
+	PriceSourcePerYear         map[string]string  // Key: Year, Value: Provider Name.
+}
+
+// SetDividends stores the individual payments sorted by date, detects the payout frequency with the special
+// payments and aggregates them by year.
+func (e *ETF) SetDividends(dividends []Dividend) {
+	e.Dividends = dividends
+	SortDividends(e.Dividends)
+	e.Frequency = ClassifyDividends(e.Dividends)
+	e.AmountDividendsPerYear = SumDividendsPerYear(e.Dividends)
+	e.DividendSourcePerYear = DividendSourcePerYear(e.Dividends)
+}
+
+// LastDividend returns the most recent payment.
+func (e *ETF) LastDividend() (Dividend, bool) {
+	if len(e.Dividends) == 0 {
+		return Dividend{}, false
+	}
+
+	return e.Dividends[len(e.Dividends)-1], true
+}
+
+// NextExDate returns the first ex-date after the given moment, when the provider has already announced it.
+func (e *ETF) NextExDate(now time.Time) (time.Time, bool) {
+	for _, dividend := range e.Dividends {
+		if dividend.ExDate.After(now) {
+			return dividend.ExDate, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// PaymentChangePercentage returns how much the payment at the given index changed compared to the previous one.
+func (e *ETF) PaymentChangePercentage(index int) (float64, bool) {
+	if index <= 0 || index >= len(e.Dividends) {
+		return 0, false
+	}
+
+	previous := e.Dividends[index-1].Amount
+	if previous == 0 {
+		return 0, false
+	}
+
+	return (e.Dividends[index].Amount - previous) / previous * PercentageMultiplier, true
+}
+
+// LastPaymentChangePercentage returns how much the most recent payment changed compared to the previous one.
+func (e *ETF) LastPaymentChangePercentage() (float64, bool) {
+	return e.PaymentChangePercentage(len(e.Dividends) - 1)
+}
+
+// SetPriceBars stores the daily bars sorted by date and averages their closing prices by year.
+func (e *ETF) SetPriceBars(bars []PriceBar) {
+	e.PriceBars = bars
+	SortPriceBars(e.PriceBars)
+	e.AverageClosingPricePerYear = AverageClosingPricePerYear(e.PriceBars)
+	e.PriceSourcePerYear = PriceSourcePerYear(e.PriceBars)
+}
+
+// LastPriceBar returns the most recent trading day.
+func (e *ETF) LastPriceBar() (PriceBar, bool) {
+	if len(e.PriceBars) == 0 {
+		return PriceBar{}, false
+	}
+
+	return e.PriceBars[len(e.PriceBars)-1], true
 }
 
 // ShowDividendsPerYear formats the yearly sums for table display.
@@ -23,7 +99,7 @@ func (e *ETF) ShowDividendsPerYear(startYear, totalYears int) []string {
 	for i := range totalYears {
 		year := strconv.Itoa(startYear - i)
 		if value, exists := e.AmountDividendsPerYear[year]; exists {
-			formatted[i] = fmt.Sprintf("$%.3f", value)
+			formatted[i] = e.FormatAmount(value)
 		} else {
 			formatted[i] = "-"
 		}
@@ -64,7 +140,7 @@ func (e *ETF) ShowClosingPricesPerYear(startYear, totalYears int) []string {
 	for i := range totalYears {
 		year := strconv.Itoa(startYear - i)
 		if value, exists := e.AverageClosingPricePerYear[year]; exists {
-			formatted[i] = fmt.Sprintf("$%.3f", value)
+			formatted[i] = e.FormatAmount(value)
 		} else {
 			formatted[i] = "-"
 		}
@@ -98,21 +174,39 @@ func (e *ETF) AverageClosingPrices(startYear, totalYears int) float64 {
 	return sum / float64(count)
 }
 
-// ShowDividendYieldPerYear calculates the dividend yield for each year and stores it in the ETF struct.
+// CalculateDividendYieldPerYear calculates the dividend yield for each year and stores it in the ETF struct.
+func (e *ETF) CalculateDividendYieldPerYear(startYear, totalYears int) map[string]float64 {
+	e.DividendYieldPerYear = e.dividendYieldPerYear(startYear, totalYears)
+
+	return e.DividendYieldPerYear
+}
+
+// dividendYieldPerYear calculates the dividend yield for each year without storing it.
+func (e *ETF) dividendYieldPerYear(startYear, totalYears int) map[string]float64 {
+	yields := make(map[string]float64)
+
+	for i := range totalYears {
+		year := strconv.Itoa(startYear - i)
+		dividend, dividendExists := e.AmountDividendsPerYear[year]
+		closingPrice, priceExists := e.AverageClosingPricePerYear[year]
+
+		if dividendExists && priceExists && closingPrice != 0 {
+			yields[year] = (dividend / closingPrice) * PercentageMultiplier
+		}
+	}
+
+	return yields
+}
+
+// ShowDividendYieldPerYear calculates the dividend yield for each year and formats it for table display.
 func (e *ETF) ShowDividendYieldPerYear(startYear, totalYears int) []string {
 	formatted := make([]string, totalYears)
-	e.DividendYieldPerYear = make(map[string]float64)
+	yields := e.CalculateDividendYieldPerYear(startYear, totalYears)
 
 	for i := range totalYears {
 		year := strconv.Itoa(startYear - i)
-		if dividend, dividendExists := e.AmountDividendsPerYear[year]; dividendExists {
-			if closingPrice, priceExists := e.AverageClosingPricePerYear[year]; priceExists && closingPrice != 0 {
-				yield := (dividend / closingPrice) * PercentageMultiplier
-				e.DividendYieldPerYear[year] = yield
-				formatted[i] = fmt.Sprintf("%.3f%%", yield)
-			} else {
-				formatted[i] = "-"
-			}
+		if yield, exists := yields[year]; exists {
+			formatted[i] = fmt.Sprintf("%.3f%%", yield)
 		} else {
 			formatted[i] = "-"
 		}
@@ -123,17 +217,18 @@ func (e *ETF) ShowDividendYieldPerYear(startYear, totalYears int) []string {
 
 // AverageDividendYield calculates the average dividend yield for the specified years.
 func (e *ETF) AverageDividendYield(startYear int, totalYears int) float64 {
-	if len(e.DividendYieldPerYear) == 0 {
-		return 0
-	}
+	return averageYield(e.DividendYieldPerYear, startYear, totalYears)
+}
 
+// averageYield calculates the average of the yields of the specified years.
+func averageYield(yields map[string]float64, startYear, totalYears int) float64 {
 	var sum float64
 
 	var count int
 
 	for i := range totalYears {
 		year := strconv.Itoa(startYear - i)
-		if yield, exists := e.DividendYieldPerYear[year]; exists {
+		if yield, exists := yields[year]; exists {
 			sum += yield
 			count++
 		}
@@ -145,3 +240,27 @@ func (e *ETF) AverageDividendYield(startYear int, totalYears int) float64 {
 
 	return sum / float64(count)
 }
+
+// ShowSourcesPerYear formats the providers that supplied the dividends and the prices of each year for table
+// display, naming both only when they differ.
+func (e *ETF) ShowSourcesPerYear(startYear, totalYears int) []string {
+	formatted := make([]string, totalYears)
+
+	for i := range totalYears {
+		year := strconv.Itoa(startYear - i)
+		dividendSource, priceSource := e.DividendSourcePerYear[year], e.PriceSourcePerYear[year]
+
+		switch {
+		case dividendSource == "" && priceSource == "":
+			formatted[i] = "-"
+		case dividendSource == priceSource || priceSource == "":
+			formatted[i] = dividendSource
+		case dividendSource == "":
+			formatted[i] = priceSource
+		default:
+			formatted[i] = dividendSource + " / " + priceSource
+		}
+	}
+
+	return formatted
+}
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// DefaultCutThresholdPercentage is the smallest drop of the yearly dividends, in percent, considered a cut.
+const DefaultCutThresholdPercentage = 10
+
+// DividendCut represents a year whose dividends dropped from the previous year by more than the threshold.
+type DividendCut struct {
+	Year             int
+	PreviousAmount   float64
+	Amount           float64
+	ChangePercentage float64 // Negative percentage change from the previous year.
+}
+
+// DividendGrowthScreen holds the dividend growth criteria an ETF must meet to be kept in a screen.
+// Zero values disable each criterion.
+type DividendGrowthScreen struct {
+	CAGRYears              int // Period of the minimum compound annual growth rate.
+	MinimumCAGRPercentage  float64
+	MinimumStreak          int // Consecutive years of increase.
+	NoCutsYears            int // Number of recent complete years that must not have any cut.
+	CutThresholdPercentage float64
+}
+
+// CAGRPeriods lists the periods, in years, of the dividend growth rates shown in the report.
+func CAGRPeriods() []int {
+	return []int{1, 3, 5, 10}
+}
+
+// completeYears returns the first and the last years whose dividends are complete: the current year is still
+// being paid, and the year of the first payment may have started after the fund was launched.
+func (e *ETF) completeYears(currentYear int) (int, int, bool) {
+	if len(e.Dividends) == 0 {
+		return 0, 0, false
+	}
+
+	firstYear := e.Dividends[0].Date().Year() + 1
+	lastYear := currentYear - 1
+
+	return firstYear, lastYear, firstYear <= lastYear
+}
+
+// dividendsOf returns the dividends paid in the year, zero when nothing was paid.
+func (e *ETF) dividendsOf(year int) float64 {
+	return e.AmountDividendsPerYear[strconv.Itoa(year)]
+}
+
+// DividendCAGR calculates the compound annual growth rate percentage of the yearly dividends over the period
+// ending on the last complete year.
+func (e *ETF) DividendCAGR(currentYear, years int) (float64, bool) {
+	firstYear, lastYear, exists := e.completeYears(currentYear)
+	if !exists || years <= 0 || lastYear-years < firstYear {
+		return 0, false
+	}
+
+	start, end := e.dividendsOf(lastYear-years), e.dividendsOf(lastYear)
+	if start <= 0 {
+		return 0, false
+	}
+
+	return (math.Pow(end/start, 1/float64(years)) - 1) * PercentageMultiplier, true
+}
+
+// DividendGrowthStreak counts the consecutive complete years, up to the last one, whose dividends increased over
+// the previous year.
+func (e *ETF) DividendGrowthStreak(currentYear int) int {
+	firstYear, lastYear, exists := e.completeYears(currentYear)
+	if !exists {
+		return 0
+	}
+
+	var streak int
+
+	for year := lastYear; year > firstYear && e.dividendsOf(year) > e.dividendsOf(year-1); year-- {
+		streak++
+	}
+
+	return streak
+}
+
+// DividendCuts lists, from the oldest, the complete years whose dividends dropped from the previous year by more
+// than the threshold percentage. A year without any payment after a paying one is a cut of the whole amount.
+func (e *ETF) DividendCuts(currentYear int, thresholdPercentage float64) []DividendCut {
+	firstYear, lastYear, exists := e.completeYears(currentYear)
+	if !exists {
+		return nil
+	}
+
+	var cuts []DividendCut
+
+	for year := firstYear + 1; year <= lastYear; year++ {
+		previous, amount := e.dividendsOf(year-1), e.dividendsOf(year)
+		if previous <= 0 {
+			continue
+		}
+
+		change := (amount - previous) / previous * PercentageMultiplier
+		if -change > thresholdPercentage {
+			cuts = append(cuts, DividendCut{
+				Year:             year,
+				PreviousAmount:   previous,
+				Amount:           amount,
+				ChangePercentage: change,
+			})
+		}
+	}
+
+	return cuts
+}
+
+// LastDividendCut returns the most recent cut larger than the threshold percentage.
+func (e *ETF) LastDividendCut(currentYear int, thresholdPercentage float64) (DividendCut, bool) {
+	cuts := e.DividendCuts(currentYear, thresholdPercentage)
+	if len(cuts) == 0 {
+		return DividendCut{}, false
+	}
+
+	return cuts[len(cuts)-1], true
+}
+
+// MeetsDividendGrowthScreen reports whether the ETF meets every enabled criterion of the screen. The ETFs without
+// enough history to calculate a criterion do not meet it.
+func (e *ETF) MeetsDividendGrowthScreen(currentYear int, screen DividendGrowthScreen) bool {
+	if screen.CAGRYears > 0 {
+		cagr, exists := e.DividendCAGR(currentYear, screen.CAGRYears)
+		if !exists || cagr < screen.MinimumCAGRPercentage {
+			return false
+		}
+	}
+
+	if e.DividendGrowthStreak(currentYear) < screen.MinimumStreak {
+		return false
+	}
+
+	if screen.NoCutsYears > 0 {
+		if cut, exists := e.LastDividendCut(currentYear, screen.CutThresholdPercentage); exists &&
+			cut.Year > currentYear-1-screen.NoCutsYears {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ShowDividendGrowth formats the dividend growth rates of every period, the growth streak and the last cut for
+// table display, in the same order as the report headers.
+func (e *ETF) ShowDividendGrowth(currentYear int, thresholdPercentage float64) []string {
+	formatted := make([]string, 0, len(CAGRPeriods())+2)
+
+	for _, years := range CAGRPeriods() {
+		if cagr, exists := e.DividendCAGR(currentYear, years); exists {
+			formatted = append(formatted, fmt.Sprintf("%+.2f%%", cagr))
+		} else {
+			formatted = append(formatted, "-")
+		}
+	}
+
+	formatted = append(formatted, strconv.Itoa(e.DividendGrowthStreak(currentYear)))
+
+	if cut, exists := e.LastDividendCut(currentYear, thresholdPercentage); exists {
+		formatted = append(formatted, fmt.Sprintf("%d (%.2f%%)", cut.Year, cut.ChangePercentage))
+	} else {
+		formatted = append(formatted, "-")
+	}
+
+	return formatted
+}
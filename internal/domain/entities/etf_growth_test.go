@@ -0,0 +1,120 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ETFGrowthTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *ETFGrowthTestSuite) SetupTest() {
+	yearlySums := map[int]float64{
+		2015: 0.3, 2016: 1.0, 2017: 1.1, 2018: 0.9, 2019: 1.0, 2020: 1.2,
+		2021: 1.3, 2022: 1.5, 2023: 1.6, 2024: 1.8, 2025: 0.5,
+	}
+
+	dividends := make([]entities.Dividend, 0, len(yearlySums))
+	for year, amount := range yearlySums {
+		dividends = append(dividends, entities.Dividend{PaymentDate: date(year, time.June, 15), Amount: amount})
+	}
+
+	suite.etf = entities.NewETF("SCHD")
+	suite.etf.SetDividends(dividends)
+}
+
+func (suite *ETFGrowthTestSuite) TestDividendCAGR() {
+	suite.Run("should compound the growth up to the last complete year", func() {
+		// given
+		// on the setup
+
+		// when
+		oneYear, oneYearExists := suite.etf.DividendCAGR(2025, 1)
+		fiveYears, fiveYearsExists := suite.etf.DividendCAGR(2025, 5)
+		_, tenYearsExists := suite.etf.DividendCAGR(2025, 10)
+
+		// then
+		suite.True(oneYearExists)
+		suite.InDelta(12.5, oneYear, 0.01)
+		suite.True(fiveYearsExists)
+		suite.InDelta(12.47, fiveYears, 0.01)
+		suite.False(tenYearsExists, "the first year of the history is incomplete")
+	})
+}
+
+func (suite *ETFGrowthTestSuite) TestDividendGrowthStreak() {
+	suite.Run("should count the consecutive years of increase since the last decrease", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.DividendGrowthStreak(2025)
+
+		// then
+		suite.Equal(6, result)
+	})
+}
+
+func (suite *ETFGrowthTestSuite) TestDividendCuts() {
+	suite.Run("should detect the years whose dividends dropped by more than the threshold", func() {
+		// given
+		// on the setup
+
+		// when
+		cuts := suite.etf.DividendCuts(2025, entities.DefaultCutThresholdPercentage)
+		_, exists := suite.etf.LastDividendCut(2025, 20)
+
+		// then
+		suite.Len(cuts, 1)
+		suite.Equal(2018, cuts[0].Year)
+		suite.InDelta(-18.18, cuts[0].ChangePercentage, 0.01)
+		suite.False(exists)
+	})
+}
+
+func (suite *ETFGrowthTestSuite) TestMeetsDividendGrowthScreen() {
+	suite.Run("should keep only the ETFs meeting every enabled criterion", func() {
+		// given
+		screen := entities.DividendGrowthScreen{
+			CAGRYears:              5,
+			MinimumCAGRPercentage:  10,
+			NoCutsYears:            5,
+			CutThresholdPercentage: entities.DefaultCutThresholdPercentage,
+		}
+
+		// when
+		meets := suite.etf.MeetsDividendGrowthScreen(2025, screen)
+		screen.MinimumStreak = 7
+		streakTooShort := suite.etf.MeetsDividendGrowthScreen(2025, screen)
+		screen.MinimumStreak, screen.NoCutsYears = 0, 7
+		recentCut := suite.etf.MeetsDividendGrowthScreen(2025, screen)
+
+		// then
+		suite.True(meets)
+		suite.False(streakTooShort)
+		suite.False(recentCut)
+	})
+}
+
+func (suite *ETFGrowthTestSuite) TestShowDividendGrowth() {
+	suite.Run("should format the growth rates, the streak and the last cut", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.ShowDividendGrowth(2025, entities.DefaultCutThresholdPercentage)
+
+		// then
+		suite.Equal([]string{"+12.50%", "+11.46%", "+12.47%", "-", "6", "2018 (-18.18%)"}, result)
+	})
+}
+
+func TestETFGrowthTestSuite(t *testing.T) {
+	suite.Run(t, new(ETFGrowthTestSuite))
+}
@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Returns holds the percentage returns of an ETF over a period.
+type Returns struct {
+	Price      float64 // Change of the closing price.
+	Total      float64 // Change of the closing price plus the distributions, kept in cash.
+	Reinvested float64 // Change of the closing price plus the distributions, reinvested at the close of the ex-date.
+}
+
+// Distributions returns the part of the total return paid out as distributions.
+func (r Returns) Distributions() float64 {
+	return r.Total - r.Price
+}
+
+// closeOn returns the last bar on or before the date.
+func (e *ETF) closeOn(date time.Time) (PriceBar, bool) {
+	// The index of the first bar after the date, so the previous one is the last close on it.
+	index := sort.Search(len(e.PriceBars), func(i int) bool {
+		return e.PriceBars[i].Date.After(date)
+	})
+
+	if index == 0 {
+		return PriceBar{}, false
+	}
+
+	return e.PriceBars[index-1], true
+}
+
+// barsBetween returns the bars from the last close before the start, or the first close on or after it when the
+// history begins later, to the last close on or before the end.
+func (e *ETF) barsBetween(start, end time.Time) []PriceBar {
+	// The index of the first bar on or after the start, so the previous one is the last close before it.
+	first := sort.Search(len(e.PriceBars), func(i int) bool {
+		return !e.PriceBars[i].Date.Before(start)
+	})
+	if first > 0 {
+		first--
+	}
+
+	// The index of the first bar after the end, so the bars up to it close on or before the end.
+	last := sort.Search(len(e.PriceBars), func(i int) bool {
+		return e.PriceBars[i].Date.After(end)
+	})
+
+	if first >= last {
+		return nil
+	}
+
+	return e.PriceBars[first:last]
+}
+
+// ReturnsBetween calculates the returns from the last close before the start, or the first close on or after it
+// when the history begins later, to the last close on or before the end. The distributions count from the day
+// after the first close up to the last one, by their ex-dates.
+func (e *ETF) ReturnsBetween(start, end time.Time) (Returns, bool) {
+	bars := e.barsBetween(start, end)
+	if len(bars) < 2 || bars[0].Close == 0 {
+		return Returns{}, false
+	}
+
+	first, last := bars[0], bars[len(bars)-1]
+
+	var distributions float64
+
+	shares := 1.0
+
+	for _, dividend := range e.Dividends {
+		exDate := exDateOf(dividend)
+		if !exDate.After(first.Date) || exDate.After(last.Date) {
+			continue
+		}
+
+		distributions += dividend.Amount
+
+		if bar, barExists := e.closeOn(exDate); barExists && bar.Close != 0 {
+			shares += shares * dividend.Amount / bar.Close
+		}
+	}
+
+	return Returns{
+		Price:      (last.Close/first.Close - 1) * PercentageMultiplier,
+		Total:      ((last.Close+distributions)/first.Close - 1) * PercentageMultiplier,
+		Reinvested: (shares*last.Close/first.Close - 1) * PercentageMultiplier,
+	}, true
+}
+
+// ReturnsOf calculates the returns of the calendar year, up to the latest close in the current year.
+func (e *ETF) ReturnsOf(year int) (Returns, bool) {
+	return e.ReturnsBetween(
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+	)
+}
+
+// ReturnsOver calculates the returns over the total years up to the start year, the most recent one.
+func (e *ETF) ReturnsOver(startYear, totalYears int) (Returns, bool) {
+	return e.ReturnsBetween(
+		time.Date(startYear-totalYears+1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(startYear, time.December, 31, 0, 0, 0, 0, time.UTC),
+	)
+}
+
+// ShowTotalReturnsPerYear formats the total return of each year, with the distributions reinvested, for table
+// display.
+func (e *ETF) ShowTotalReturnsPerYear(startYear, totalYears int) []string {
+	formatted := make([]string, totalYears)
+
+	for i := range totalYears {
+		if returns, exists := e.ReturnsOf(startYear - i); exists {
+			formatted[i] = fmt.Sprintf("%+.2f%%", returns.Reinvested)
+		} else {
+			formatted[i] = "-"
+		}
+	}
+
+	return formatted
+}
+
+// AverageTotalReturn calculates the average of the yearly total returns, with the distributions reinvested, for the
+// specified years.
+func (e *ETF) AverageTotalReturn(startYear, totalYears int) (float64, bool) {
+	var sum float64
+
+	var count int
+
+	for i := range totalYears {
+		if returns, exists := e.ReturnsOf(startYear - i); exists {
+			sum += returns.Reinvested
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / float64(count), true
+}
+
+// NAVErosion calculates the percentage of the distributions paid over the total years that the fall of the price
+// took back, comparing the price return to the distribution yield. Funds whose price held up erode nothing, and
+// above 100% the total return is negative.
+func (e *ETF) NAVErosion(startYear, totalYears int) (float64, bool) {
+	returns, exists := e.ReturnsOver(startYear, totalYears)
+	if !exists || returns.Distributions() <= 0 {
+		return 0, false
+	}
+
+	return max(0, -returns.Price) / returns.Distributions() * PercentageMultiplier, true
+}
+
+// IsYieldTrap reports whether the total return over the total years is negative while the average dividend
+// yield reaches the target percentage: the high yield hides a falling price. The yields are calculated without
+// storing them, so the ETF is left as it was.
+func (e *ETF) IsYieldTrap(startYear, totalYears int, targetYieldPercentage float64) bool {
+	returns, exists := e.ReturnsOver(startYear, totalYears)
+	if !exists || returns.Total >= 0 {
+		return false
+	}
+
+	yields := e.dividendYieldPerYear(startYear, totalYears)
+
+	return averageYield(yields, startYear, totalYears) >= targetYieldPercentage
+}
+
+// ShowNAVErosion formats the NAV erosion over the total years for table display, flagging the yield traps.
+func (e *ETF) ShowNAVErosion(startYear, totalYears int, targetYieldPercentage float64) string {
+	erosion, exists := e.NAVErosion(startYear, totalYears)
+	if !exists {
+		return "-"
+	}
+
+	formatted := fmt.Sprintf("%.2f%%", erosion)
+	if e.IsYieldTrap(startYear, totalYears, targetYieldPercentage) {
+		formatted += " (yield trap)"
+	}
+
+	return formatted
+}
@@ -0,0 +1,123 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ETFReturnsTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *ETFReturnsTestSuite) SetupTest() {
+	suite.etf = entities.NewETF("SVOL")
+	suite.etf.SetPriceBars([]entities.PriceBar{
+		{Date: date(2023, time.December, 29), Close: 100},
+		{Date: date(2024, time.March, 15), Close: 95},
+		{Date: date(2024, time.June, 14), Close: 90},
+		{Date: date(2024, time.December, 31), Close: 85},
+	})
+	suite.etf.SetDividends([]entities.Dividend{
+		{ExDate: date(2024, time.March, 15), PaymentDate: date(2024, time.March, 22), Amount: 3},
+		{ExDate: date(2024, time.June, 14), PaymentDate: date(2024, time.June, 21), Amount: 3},
+	})
+}
+
+func (suite *ETFReturnsTestSuite) TestReturnsOf() {
+	suite.Run("should add the distributions to the price change, in cash and reinvested", func() {
+		// given
+		// on the setup
+
+		// when
+		returns, exists := suite.etf.ReturnsOf(2024)
+		_, previousExists := suite.etf.ReturnsOf(2023)
+
+		// then
+		suite.True(exists)
+		suite.InDelta(-15.0, returns.Price, 0.001)
+		suite.InDelta(-9.0, returns.Total, 0.001)
+		suite.InDelta(-9.393, returns.Reinvested, 0.001)
+		suite.False(previousExists, "a single close has no return")
+	})
+}
+
+func (suite *ETFReturnsTestSuite) TestNAVErosion() {
+	suite.Run("should compare the fall of the price to the distributions", func() {
+		// given
+		// on the setup
+
+		// when
+		erosion, exists := suite.etf.NAVErosion(2024, 1)
+
+		// then
+		suite.True(exists)
+		suite.InDelta(250.0, erosion, 0.001)
+	})
+
+	suite.Run("should not erode anything when the price held up", func() {
+		// given
+		etf := entities.NewETF("SCHD")
+		etf.SetPriceBars([]entities.PriceBar{
+			{Date: date(2023, time.December, 29), Close: 100},
+			{Date: date(2024, time.December, 31), Close: 110},
+		})
+		etf.SetDividends([]entities.Dividend{{ExDate: date(2024, time.June, 14), Amount: 3}})
+
+		// when
+		erosion, exists := etf.NAVErosion(2024, 1)
+
+		// then
+		suite.True(exists)
+		suite.Zero(erosion)
+	})
+}
+
+func (suite *ETFReturnsTestSuite) TestIsYieldTrap() {
+	suite.Run("should flag a negative total return only when the yield reaches the target", func() {
+		// given
+		// on the setup
+
+		// when
+		belowTarget := suite.etf.IsYieldTrap(2024, 1, 9)
+		aboveTarget := suite.etf.IsYieldTrap(2024, 1, 6)
+
+		// then
+		suite.False(belowTarget)
+		suite.True(aboveTarget)
+		suite.Equal("250.00% (yield trap)", suite.etf.ShowNAVErosion(2024, 1, 6))
+	})
+
+	suite.Run("should not overwrite the yields already calculated for the report", func() {
+		// given
+		yields := map[string]float64{"2023": 1.5}
+		suite.etf.DividendYieldPerYear = yields
+
+		// when
+		suite.etf.IsYieldTrap(2024, 1, 6)
+
+		// then
+		suite.Equal(map[string]float64{"2023": 1.5}, suite.etf.DividendYieldPerYear)
+	})
+}
+
+func (suite *ETFReturnsTestSuite) TestShowTotalReturnsPerYear() {
+	suite.Run("should format the reinvested total return of each year", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.ShowTotalReturnsPerYear(2024, 2)
+
+		// then
+		suite.Equal([]string{"-9.39%", "-"}, result)
+	})
+}
+
+func TestETFReturnsTestSuite(t *testing.T) {
+	suite.Run(t, new(ETFReturnsTestSuite))
+}
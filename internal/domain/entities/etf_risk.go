@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// TradingDaysInYear annualizes the daily returns, as the number of sessions of a year on the exchanges.
+const TradingDaysInYear = 252
+
+// Drawdown holds the largest fall of the closing price from a previous peak.
+type Drawdown struct {
+	Percentage float64   // Fall from the peak to the trough, as a negative percentage.
+	Peak       time.Time // Date of the highest close before the fall.
+	Trough     time.Time // Date of the lowest close of the fall.
+}
+
+// Risk holds the risk metrics of an ETF over a period, calculated from its daily total returns.
+type Risk struct {
+	Return                 float64 // Annualized mean of the daily total returns, in percent.
+	Volatility             float64 // Annualized standard deviation of the daily total returns, in percent.
+	DownsideDeviation      float64 // Annualized deviation of the daily total returns below the risk-free rate.
+	RiskFreeRatePercentage float64 // Yearly rate of the risk-free asset the returns are compared to.
+	MaxDrawdown            Drawdown
+}
+
+// Sharpe returns the excess return over the risk-free rate per unit of volatility.
+func (r Risk) Sharpe() (float64, bool) {
+	if r.Volatility == 0 {
+		return 0, false
+	}
+
+	return (r.Return - r.RiskFreeRatePercentage) / r.Volatility, true
+}
+
+// Sortino returns the excess return over the risk-free rate per unit of downside deviation, so only the falls
+// count as risk.
+func (r Risk) Sortino() (float64, bool) {
+	if r.DownsideDeviation == 0 {
+		return 0, false
+	}
+
+	return (r.Return - r.RiskFreeRatePercentage) / r.DownsideDeviation, true
+}
+
+// RiskBetween calculates the risk metrics over the same closes as ReturnsBetween. Each daily return adds the
+// distributions whose ex-date falls on the day, and at least two of them are needed for the deviations.
+func (e *ETF) RiskBetween(start, end time.Time, riskFreeRatePercentage float64) (Risk, bool) {
+	bars := e.barsBetween(start, end)
+	if len(bars) < 3 {
+		return Risk{}, false
+	}
+
+	returns := make([]float64, 0, len(bars)-1)
+	for i := 1; i < len(bars); i++ {
+		if bars[i-1].Close == 0 {
+			return Risk{}, false
+		}
+
+		returns = append(returns, e.totalReturnBetween(bars[i-1], bars[i]))
+	}
+
+	var mean float64
+	for _, value := range returns {
+		mean += value
+	}
+
+	mean /= float64(len(returns))
+
+	// The daily return the risk-free asset pays, below which a return counts as a loss for the Sortino ratio.
+	riskFreeDaily := riskFreeRatePercentage / PercentageMultiplier / TradingDaysInYear
+
+	var variance, downside float64
+
+	for _, value := range returns {
+		variance += (value - mean) * (value - mean)
+		if value < riskFreeDaily {
+			downside += (value - riskFreeDaily) * (value - riskFreeDaily)
+		}
+	}
+
+	annualize := math.Sqrt(TradingDaysInYear) * PercentageMultiplier
+
+	return Risk{
+		Return:                 mean * TradingDaysInYear * PercentageMultiplier,
+		Volatility:             math.Sqrt(variance/float64(len(returns)-1)) * annualize,
+		DownsideDeviation:      math.Sqrt(downside/float64(len(returns))) * annualize,
+		RiskFreeRatePercentage: riskFreeRatePercentage,
+		MaxDrawdown:            maxDrawdownOf(bars),
+	}, true
+}
+
+// distributionsBetween sums the distributions whose ex-date falls after the first date and on or before the last.
+func (e *ETF) distributionsBetween(after, through time.Time) float64 {
+	var sum float64
+
+	for _, dividend := range e.Dividends {
+		if exDate := exDateOf(dividend); exDate.After(after) && !exDate.After(through) {
+			sum += dividend.Amount
+		}
+	}
+
+	return sum
+}
+
+// totalReturnBetween returns the change from the previous close to the current one, plus the distributions whose
+// ex-date falls in between, as a decimal ratio.
+func (e *ETF) totalReturnBetween(previous, current PriceBar) float64 {
+	return (current.Close+e.distributionsBetween(previous.Date, current.Date))/previous.Close - 1
+}
+
+// maxDrawdownOf finds the largest fall of the closing price from a previous peak, or a zero drawdown when the
+// price never closed below a previous peak.
+func maxDrawdownOf(bars []PriceBar) Drawdown {
+	var drawdown Drawdown
+
+	peak := bars[0]
+	for _, bar := range bars[1:] {
+		if bar.Close > peak.Close {
+			peak = bar
+
+			continue
+		}
+
+		if peak.Close == 0 {
+			continue
+		}
+
+		if fall := (bar.Close/peak.Close - 1) * PercentageMultiplier; fall < drawdown.Percentage {
+			drawdown = Drawdown{Percentage: fall, Peak: peak.Date, Trough: bar.Date}
+		}
+	}
+
+	return drawdown
+}
+
+// RiskOf calculates the risk metrics of the calendar year, up to the latest close in the current year.
+func (e *ETF) RiskOf(year int, riskFreeRatePercentage float64) (Risk, bool) {
+	return e.RiskBetween(
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+		riskFreeRatePercentage,
+	)
+}
+
+// RiskOver calculates the risk metrics over the total years up to the start year, the most recent one.
+func (e *ETF) RiskOver(startYear, totalYears int, riskFreeRatePercentage float64) (Risk, bool) {
+	return e.RiskBetween(
+		time.Date(startYear-totalYears+1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(startYear, time.December, 31, 0, 0, 0, 0, time.UTC),
+		riskFreeRatePercentage,
+	)
+}
+
+// ShowRisk formats the volatility, the maximum drawdown with the date of its trough, and the Sharpe and Sortino
+// ratios over the total years for table display, using dashes when they are not available.
+func (e *ETF) ShowRisk(startYear, totalYears int, riskFreeRatePercentage float64) []string {
+	formatted := []string{"-", "-", "-", "-"}
+
+	risk, exists := e.RiskOver(startYear, totalYears, riskFreeRatePercentage)
+	if !exists {
+		return formatted
+	}
+
+	formatted[0] = fmt.Sprintf("%.2f%%", risk.Volatility)
+
+	if drawdown := risk.MaxDrawdown; drawdown.Trough.IsZero() {
+		formatted[1] = "0.00%"
+	} else {
+		formatted[1] = fmt.Sprintf("%.2f%% (%s)", drawdown.Percentage, drawdown.Trough.Format(time.DateOnly))
+	}
+
+	if sharpe, sharpeExists := risk.Sharpe(); sharpeExists {
+		formatted[2] = fmt.Sprintf("%.2f", sharpe)
+	}
+
+	if sortino, sortinoExists := risk.Sortino(); sortinoExists {
+		formatted[3] = fmt.Sprintf("%.2f", sortino)
+	}
+
+	return formatted
+}
@@ -0,0 +1,130 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ETFRiskTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *ETFRiskTestSuite) SetupTest() {
+	suite.etf = entities.NewETF("SVOL")
+	suite.etf.SetPriceBars([]entities.PriceBar{
+		{Date: date(2024, time.January, 2), Close: 100},
+		{Date: date(2024, time.January, 3), Close: 110},
+		{Date: date(2024, time.January, 4), Close: 99},
+		{Date: date(2024, time.January, 5), Close: 99},
+	})
+	suite.etf.SetDividends([]entities.Dividend{
+		{ExDate: date(2024, time.January, 5), PaymentDate: date(2024, time.January, 12), Amount: 9.9},
+	})
+}
+
+func (suite *ETFRiskTestSuite) TestRiskOf() {
+	suite.Run("should annualize the deviations of the daily returns with the distributions", func() {
+		// given
+		// on the setup
+
+		// when
+		risk, exists := suite.etf.RiskOf(2024, 0)
+
+		// then
+		suite.True(exists)
+		suite.InDelta(840.0, risk.Return, 0.001)
+		suite.InDelta(183.303, risk.Volatility, 0.001)
+		suite.InDelta(91.652, risk.DownsideDeviation, 0.001)
+	})
+
+	suite.Run("should find the largest fall of the closing price with its peak and trough", func() {
+		// given
+		// on the setup
+
+		// when
+		risk, _ := suite.etf.RiskOf(2024, 0)
+
+		// then
+		suite.InDelta(-10.0, risk.MaxDrawdown.Percentage, 0.001)
+		suite.Equal(date(2024, time.January, 3), risk.MaxDrawdown.Peak)
+		suite.Equal(date(2024, time.January, 4), risk.MaxDrawdown.Trough)
+	})
+
+	suite.Run("should not be available with fewer than two daily returns", func() {
+		// given
+		// on the setup
+
+		// when
+		_, exists := suite.etf.RiskBetween(date(2024, time.January, 5), date(2024, time.January, 5), 0)
+
+		// then
+		suite.False(exists)
+	})
+}
+
+func (suite *ETFRiskTestSuite) TestSharpeAndSortino() {
+	suite.Run("should divide the excess return by the volatility and by the downside deviation", func() {
+		// given
+		risk, _ := suite.etf.RiskOf(2024, 4)
+
+		// when
+		sharpe, sharpeExists := risk.Sharpe()
+		sortino, sortinoExists := risk.Sortino()
+
+		// then
+		suite.True(sharpeExists)
+		suite.InDelta(4.561, sharpe, 0.001)
+		suite.True(sortinoExists)
+		suite.Greater(sortino, sharpe)
+	})
+
+	suite.Run("should not calculate the Sortino ratio without any daily loss", func() {
+		// given
+		etf := entities.NewETF("SGOV")
+		etf.SetPriceBars([]entities.PriceBar{
+			{Date: date(2024, time.January, 2), Close: 100},
+			{Date: date(2024, time.January, 3), Close: 101},
+			{Date: date(2024, time.January, 4), Close: 103},
+		})
+		risk, _ := etf.RiskOf(2024, 0)
+
+		// when
+		_, exists := risk.Sortino()
+
+		// then
+		suite.False(exists)
+	})
+}
+
+func (suite *ETFRiskTestSuite) TestShowRisk() {
+	suite.Run("should format the risk metrics with the trough of the maximum drawdown", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.ShowRisk(2024, 1, 0)
+
+		// then
+		suite.Equal([]string{"183.30%", "-10.00% (2024-01-04)", "4.58", "9.17"}, result)
+	})
+
+	suite.Run("should return dashes without prices", func() {
+		// given
+		etf := entities.NewETF("SVOL")
+
+		// when
+		result := etf.ShowRisk(2024, 1, 0)
+
+		// then
+		suite.Equal([]string{"-", "-", "-", "-"}, result)
+	})
+}
+
+func TestETFRiskTestSuite(t *testing.T) {
+	suite.Run(t, new(ETFRiskTestSuite))
+}
@@ -2,6 +2,7 @@ package entities_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/rios0rios0/investmate/internal/domain/entities"
 	"github.com/stretchr/testify/suite"
@@ -104,6 +105,21 @@ func (suite *ETFTestSuite) TestShowDividendYieldPerYear() {
 	})
 }
 
+func (suite *ETFTestSuite) TestCalculateDividendYieldPerYear() {
+	suite.Run("should only calculate the yield for years with both dividends and closing prices", func() {
+		// given
+		suite.etf.AverageClosingPricePerYear["2020"] = 100.0
+
+		// when
+		result := suite.etf.CalculateDividendYieldPerYear(2023, 5)
+
+		// then
+		expected := map[string]float64{"2023": 10.0, "2022": 10.0, "2021": 10.0}
+		suite.Equal(expected, result)
+		suite.Equal(expected, suite.etf.DividendYieldPerYear)
+	})
+}
+
 func (suite *ETFTestSuite) TestAverageDividendYield() {
 	suite.Run("should calculate average dividend yield", func() {
 		// given
@@ -117,6 +133,30 @@ func (suite *ETFTestSuite) TestAverageDividendYield() {
 	})
 }
 
+func (suite *ETFTestSuite) TestShowSourcesPerYear() {
+	suite.Run("should name the provider of each year and both providers when they differ", func() {
+		// given
+		etf := &entities.ETF{}
+		etf.SetDividends([]entities.Dividend{
+			{PaymentDate: date(2023, time.March, 1), Amount: 1, Source: "nasdaq"},
+			{PaymentDate: date(2022, time.March, 1), Amount: 1, Source: "historyorg"},
+			{PaymentDate: date(2021, time.March, 1), Amount: 1, Source: "historyorg"},
+			{PaymentDate: date(2021, time.June, 1), Amount: 1, Source: "statusinvest"},
+		})
+		etf.SetPriceBars([]entities.PriceBar{
+			{Date: date(2023, time.March, 1), Close: 10, Source: "nasdaq"},
+			{Date: date(2022, time.March, 1), Close: 10, Source: "nasdaq"},
+		})
+
+		// when
+		result := etf.ShowSourcesPerYear(2023, 4)
+
+		// then
+		expected := []string{"nasdaq", "historyorg / nasdaq", "historyorg+statusinvest", "-"}
+		suite.Equal(expected, result)
+	})
+}
+
 func TestETFTestSuite(t *testing.T) {
 	suite.Run(t, new(ETFTestSuite))
 }
@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// TTMDividends sums the payments made in the twelve months up to the given moment.
+func (e *ETF) TTMDividends(now time.Time) float64 {
+	start := now.AddDate(-1, 0, 0)
+
+	var sum float64
+
+	for _, dividend := range e.Dividends {
+		if date := dividend.Date(); date.After(start) && !date.After(now) {
+			sum += dividend.Amount
+		}
+	}
+
+	return sum
+}
+
+// TTMYield calculates the trailing-twelve-month dividend yield percentage over the latest closing price.
+func (e *ETF) TTMYield(now time.Time) (float64, bool) {
+	bar, exists := e.LastPriceBar()
+	if !exists || bar.Close == 0 || len(e.Dividends) == 0 {
+		return 0, false
+	}
+
+	return e.TTMDividends(now) / bar.Close * PercentageMultiplier, true
+}
+
+// ForwardDividends annualizes the latest regular payment using the detected payout frequency, so a special payment
+// is not repeated over the next year.
+func (e *ETF) ForwardDividends() (float64, bool) {
+	dividend, exists := e.LastRegularDividend()
+	paymentsPerYear := e.PaymentsPerYear()
+
+	if !exists || paymentsPerYear == 0 {
+		return 0, false
+	}
+
+	return dividend.Amount * float64(paymentsPerYear), true
+}
+
+// ForwardYield calculates the forward dividend yield percentage over the latest closing price.
+func (e *ETF) ForwardYield() (float64, bool) {
+	forwardDividends, dividendsExist := e.ForwardDividends()
+	bar, barExists := e.LastPriceBar()
+
+	if !dividendsExist || !barExists || bar.Close == 0 {
+		return 0, false
+	}
+
+	return forwardDividends / bar.Close * PercentageMultiplier, true
+}
+
+// PaymentsPerYear returns how many regular payments the ETF makes per year, from its detected payout frequency.
+// It returns zero when there are not enough payments to detect the cadence.
+func (e *ETF) PaymentsPerYear() int {
+	return e.Frequency.PaymentsPerYear()
+}
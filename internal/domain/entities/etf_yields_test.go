@@ -0,0 +1,97 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ETFYieldsTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *ETFYieldsTestSuite) SetupTest() {
+	suite.etf = &entities.ETF{Name: "TestETF"}
+	suite.etf.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2024, time.June, 28), Amount: 1.0},
+		{PaymentDate: date(2024, time.September, 30), Amount: 1.0},
+		{PaymentDate: date(2024, time.December, 31), Amount: 1.0},
+		{PaymentDate: date(2025, time.March, 31), Amount: 1.0},
+		{PaymentDate: date(2025, time.June, 30), Amount: 1.5},
+	})
+	suite.etf.SetPriceBars([]entities.PriceBar{
+		{Date: date(2025, time.July, 1), Close: 90.0},
+		{Date: date(2025, time.July, 2), Close: 100.0},
+	})
+}
+
+func (suite *ETFYieldsTestSuite) TestTTMYield() {
+	suite.Run("should divide the last twelve months of payments by the latest close", func() {
+		// given
+		now := date(2025, time.July, 2)
+
+		// when
+		result, exists := suite.etf.TTMYield(now)
+
+		// then
+		suite.True(exists)
+		suite.InEpsilon(4.5, result, 0.001)
+	})
+
+	suite.Run("should not be available without prices", func() {
+		// given
+		etf := &entities.ETF{Name: "TestETF", Dividends: suite.etf.Dividends}
+
+		// when
+		_, exists := etf.TTMYield(date(2025, time.July, 2))
+
+		// then
+		suite.False(exists)
+	})
+}
+
+func (suite *ETFYieldsTestSuite) TestForwardYield() {
+	suite.Run("should annualize the latest payment with the detected frequency", func() {
+		// given
+		// on the setup
+
+		// when
+		result, exists := suite.etf.ForwardYield()
+
+		// then
+		suite.True(exists)
+		suite.InEpsilon(6.0, result, 0.001)
+	})
+}
+
+func (suite *ETFYieldsTestSuite) TestPaymentsPerYear() {
+	suite.Run("should detect a quarterly payer from the gaps between payments", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.PaymentsPerYear()
+
+		// then
+		suite.Equal(4, result)
+	})
+
+	suite.Run("should not detect a frequency from a single payment", func() {
+		// given
+		etf := &entities.ETF{Dividends: suite.etf.Dividends[:1]}
+
+		// when
+		result := etf.PaymentsPerYear()
+
+		// then
+		suite.Equal(0, result)
+	})
+}
+
+func TestETFYieldsTestSuite(t *testing.T) {
+	suite.Run(t, new(ETFYieldsTestSuite))
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+)
+
+const (
+	// thousand is the threshold used to abbreviate large figures with the "K" suffix.
+	thousand = 1_000
+
+	// million is the threshold used to abbreviate large figures with the "M" suffix.
+	million = 1_000_000
+
+	// billion is the threshold used to abbreviate large figures with the "B" suffix.
+	billion = 1_000_000_000
+
+	// trillion is the threshold used to abbreviate large figures with the "T" suffix.
+	trillion = 1_000_000_000_000
+)
+
+// Fundamentals represents the descriptive figures of an ETF that are not tracked per year.
+type Fundamentals struct {
+	PayoutFrequency string    // As reported by the provider, e.g. "Monthly" or "Quarterly".
+	AverageVolume   float64   // Average number of shares traded per day.
+	ExpenseRatio    float64   // Expense Ratio Percentage.
+	Beta            float64   // Volatility relative to the market.
+	AUM             float64   // Assets Under Management.
+	InceptionDate   time.Time // Date when the fund started trading.
+}
+
+// ShowFundamentals formats the fundamentals for table display, in the same order as the report headers.
+func (f Fundamentals) ShowFundamentals() []string {
+	return f.show(cmp.Or(f.PayoutFrequency, "-"), "$")
+}
+
+// show formats the fundamentals with the given payout frequency and with the AUM in the given currency symbol.
+func (f Fundamentals) show(payoutFrequency, currencySymbol string) []string {
+	return []string{
+		payoutFrequency,
+		orDash(abbreviate(f.AverageVolume), f.AverageVolume != 0),
+		orDash(fmt.Sprintf("%.2f%%", f.ExpenseRatio), f.ExpenseRatio != 0),
+		orDash(fmt.Sprintf("%.2f", f.Beta), f.Beta != 0),
+		orDash(currencySymbol+abbreviate(f.AUM), f.AUM != 0),
+		orDash(f.InceptionDate.Format(time.DateOnly), !f.InceptionDate.IsZero()),
+	}
+}
+
+// ShowFundamentals formats the fundamentals for table display, with the AUM in the currency of the ETF.
+func (e *ETF) ShowFundamentals() []string {
+	return e.Fundamentals.show(e.ShowPayoutFrequency(), CurrencySymbol(e.Currency))
+}
+
+// orDash returns the formatted value when it is present, or a dash for table display otherwise.
+func orDash(formatted string, present bool) string {
+	if !present {
+		return "-"
+	}
+
+	return formatted
+}
+
+// abbreviate formats a large figure using the K, M, B and T suffixes.
+func abbreviate(value float64) string {
+	switch {
+	case value >= trillion:
+		return fmt.Sprintf("%.2fT", value/trillion)
+	case value >= billion:
+		return fmt.Sprintf("%.2fB", value/billion)
+	case value >= million:
+		return fmt.Sprintf("%.2fM", value/million)
+	case value >= thousand:
+		return fmt.Sprintf("%.2fK", value/thousand)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
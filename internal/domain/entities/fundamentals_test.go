@@ -0,0 +1,50 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type FundamentalsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FundamentalsTestSuite) TestShowFundamentals() {
+	suite.Run("should return formatted fundamentals in the header order", func() {
+		// given
+		fundamentals := entities.Fundamentals{
+			PayoutFrequency: "Quarterly",
+			AverageVolume:   78_123_456,
+			ExpenseRatio:    0.0945,
+			Beta:            1.0,
+			AUM:             512_300_000_000,
+			InceptionDate:   time.Date(1993, time.January, 22, 0, 0, 0, 0, time.UTC),
+		}
+
+		// when
+		result := fundamentals.ShowFundamentals()
+
+		// then
+		expected := []string{"Quarterly", "78.12M", "0.09%", "1.00", "$512.30B", "1993-01-22"}
+		suite.Equal(expected, result)
+	})
+
+	suite.Run("should return dashes when the fundamentals are missing", func() {
+		// given
+		fundamentals := entities.Fundamentals{}
+
+		// when
+		result := fundamentals.ShowFundamentals()
+
+		// then
+		expected := []string{"-", "-", "-", "-", "-", "-"}
+		suite.Equal(expected, result)
+	})
+}
+
+func TestFundamentalsTestSuite(t *testing.T) {
+	suite.Run(t, new(FundamentalsTestSuite))
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// FXRate is the value of one unit of the base currency in the quote currency on a given day.
+type FXRate struct {
+	Date  time.Time
+	Base  string // ISO 4217 code of the converted currency.
+	Quote string // ISO 4217 code of the currency the rate is expressed in.
+	Rate  float64
+}
+
+// FXSeries holds the daily rates of a currency pair, from the oldest to the most recent.
+type FXSeries []FXRate
+
+// NewFXSeries sorts the rates by date, dropping the invalid ones.
+func NewFXSeries(rates []FXRate) FXSeries {
+	series := make(FXSeries, 0, len(rates))
+	for _, rate := range rates {
+		if rate.Rate > 0 && !rate.Date.IsZero() {
+			series = append(series, rate)
+		}
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Date.Before(series[j].Date)
+	})
+
+	return series
+}
+
+// RateOn returns the rate in effect on the date: the one of the date itself or, on weekends and holidays, of the
+// last day before it. Dates before the series take its first rate, and zero dates its most recent one.
+func (s FXSeries) RateOn(date time.Time) (float64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	if date.IsZero() {
+		return s[len(s)-1].Rate, true
+	}
+
+	// The index of the first rate after the date, so the previous one is in effect on it.
+	index := sort.Search(len(s), func(i int) bool {
+		return s[i].Date.After(date)
+	})
+
+	if index == 0 {
+		return s[0].Rate, true
+	}
+
+	return s[index-1].Rate, true
+}
+
+// ConvertCurrency converts the dividends, the prices and the AUM of the ETF into the currency with the rates from
+// the native currency of the ETF, using the rate on the date of each payment and trading day.
+func (e *ETF) ConvertCurrency(currency string, rates FXSeries) {
+	if len(rates) == 0 || currency == e.Currency {
+		return
+	}
+
+	dividends := make([]Dividend, len(e.Dividends))
+	for index, dividend := range e.Dividends {
+		rate, _ := rates.RateOn(dividend.Date())
+		dividend.Amount *= rate
+		dividends[index] = dividend
+	}
+
+	bars := make([]PriceBar, len(e.PriceBars))
+	for index, bar := range e.PriceBars {
+		rate, _ := rates.RateOn(bar.Date)
+		bar.Open, bar.High, bar.Low, bar.Close = bar.Open*rate, bar.High*rate, bar.Low*rate, bar.Close*rate
+		bars[index] = bar
+	}
+
+	latest, _ := rates.RateOn(time.Time{})
+	e.AUM *= latest
+
+	e.Currency = currency
+	e.SetDividends(dividends)
+	e.SetPriceBars(bars)
+}
@@ -0,0 +1,64 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type FXRateTestSuite struct {
+	suite.Suite
+
+	rates entities.FXSeries
+}
+
+func (suite *FXRateTestSuite) SetupTest() {
+	suite.rates = entities.NewFXSeries([]entities.FXRate{
+		{Date: date(2024, time.January, 5), Base: "USD", Quote: "BRL", Rate: 4.90},
+		{Date: date(2024, time.January, 3), Base: "USD", Quote: "BRL", Rate: 4.80},
+		{Date: date(2024, time.January, 4), Base: "USD", Quote: "BRL", Rate: 0},
+	})
+}
+
+func (suite *FXRateTestSuite) TestRateOn() {
+	suite.Run("should take the last rate on or before the date", func() {
+		// given
+		// on the setup
+
+		// when
+		weekday, _ := suite.rates.RateOn(date(2024, time.January, 3))
+		weekend, _ := suite.rates.RateOn(date(2024, time.January, 7))
+		before, _ := suite.rates.RateOn(date(2023, time.December, 29))
+
+		// then
+		suite.InDelta(4.80, weekday, 0.001)
+		suite.InDelta(4.90, weekend, 0.001)
+		suite.InDelta(4.80, before, 0.001)
+	})
+}
+
+func (suite *FXRateTestSuite) TestConvertCurrency() {
+	suite.Run("should convert each payment and bar with the rate of its date", func() {
+		// given
+		etf := entities.NewETF("SPY")
+		etf.SetDividends([]entities.Dividend{{PaymentDate: date(2024, time.January, 3), Amount: 1}})
+		etf.SetPriceBars([]entities.PriceBar{{Date: date(2024, time.January, 5), Close: 100}})
+		etf.AUM = 1_000_000_000
+
+		// when
+		etf.ConvertCurrency(entities.CurrencyBRL, suite.rates)
+
+		// then
+		suite.Equal(entities.CurrencyBRL, etf.Currency)
+		suite.InDelta(4.80, etf.AmountDividendsPerYear["2024"], 0.001)
+		suite.InDelta(490.0, etf.AverageClosingPricePerYear["2024"], 0.001)
+		suite.Equal("R$4.800", etf.FormatAmount(etf.Dividends[0].Amount))
+		suite.Equal("R$4.90B", etf.ShowFundamentals()[4])
+	})
+}
+
+func TestFXRateTestSuite(t *testing.T) {
+	suite.Run(t, new(FXRateTestSuite))
+}
@@ -0,0 +1,157 @@
+package entities
+
+import "time"
+
+const (
+	// daysInWeek is the number of days between the payments of a weekly payer.
+	daysInWeek = 7
+)
+
+// IncomeRow holds the income a ticker is expected to pay in each month of the calendar, added up over every
+// position holding it.
+type IncomeRow struct {
+	Ticker   string
+	Currency string
+	Amounts  []float64 // Income of each month, in the order of the months of the calendar.
+}
+
+// IncomeCalendar holds the income the portfolio is expected to receive in each month, per ticker.
+type IncomeCalendar struct {
+	Months []time.Time // First day of each month of the calendar.
+	Rows   []IncomeRow
+}
+
+// NewIncomeCalendar projects the payments of every position over the months starting with the month of start.
+// The rows keep the order in which the tickers first appear in the portfolio.
+func NewIncomeCalendar(portfolio Portfolio, start time.Time, months int) IncomeCalendar {
+	first := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	calendar := IncomeCalendar{Months: make([]time.Time, 0, months)}
+
+	for i := range months {
+		calendar.Months = append(calendar.Months, first.AddDate(0, i, 0))
+	}
+
+	end := first.AddDate(0, months, 0)
+	byTicker := make(map[string]int)
+
+	for _, position := range portfolio.Positions {
+		index, exists := byTicker[position.Ticker]
+		if !exists {
+			index = len(calendar.Rows)
+			byTicker[position.Ticker] = index
+			calendar.Rows = append(calendar.Rows, IncomeRow{
+				Ticker:   position.Ticker,
+				Currency: position.ETF.Currency,
+				Amounts:  make([]float64, months),
+			})
+		}
+
+		for _, dividend := range position.ETF.ProjectDividends(first, end) {
+			date := dividend.Date()
+			month := (date.Year()-first.Year())*monthsInYear + int(date.Month()) - int(first.Month())
+			calendar.Rows[index].Amounts[month] += position.Shares * dividend.Amount
+		}
+	}
+
+	return calendar
+}
+
+// ProjectDividends returns the payments expected from start until before end: the ones already known, such as the
+// declared payments not paid yet, followed by the latest regular payment repeated at the payout frequency, at its
+// amount. Nothing is projected when the payout frequency is unknown.
+func (e *ETF) ProjectDividends(start, end time.Time) []Dividend {
+	var projected []Dividend
+
+	for _, dividend := range e.Dividends {
+		if date := dividend.Date(); !date.Before(start) && date.Before(end) {
+			projected = append(projected, dividend)
+		}
+	}
+
+	last, exists := e.LastRegularDividend()
+	if !exists || e.Frequency == PayoutFrequencyUnknown {
+		return projected
+	}
+
+	for step := 1; ; step++ {
+		next := Dividend{
+			ExDate:      e.Frequency.after(last.ExDate, step),
+			PaymentDate: e.Frequency.after(last.PaymentDate, step),
+			Amount:      last.Amount,
+		}
+
+		date := next.Date()
+		if !date.Before(end) {
+			return projected
+		}
+
+		if !date.Before(start) {
+			projected = append(projected, next)
+		}
+	}
+}
+
+// after returns the date the given number of payments after the date, at the cadence. The monthly and longer
+// cadences keep the day of the month, moved back to the last day of the shorter months.
+func (f PayoutFrequency) after(date time.Time, payments int) time.Time {
+	if date.IsZero() {
+		return date
+	}
+
+	if f == PayoutFrequencyWeekly {
+		return date.AddDate(0, 0, daysInWeek*payments)
+	}
+
+	months := payments * monthsInYear / f.PaymentsPerYear()
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	return firstOfMonth.AddDate(0, 0, min(date.Day(), lastDay)-1)
+}
+
+// Total returns the income of the ticker over every month of the calendar.
+func (r IncomeRow) Total() float64 {
+	var sum float64
+	for _, amount := range r.Amounts {
+		sum += amount
+	}
+
+	return sum
+}
+
+// Currency returns the currency shared by every row, or false when they are in different currencies and their
+// amounts cannot be added up.
+func (c IncomeCalendar) Currency() (string, bool) {
+	if len(c.Rows) == 0 {
+		return "", false
+	}
+
+	currency := c.Rows[0].Currency
+	for _, row := range c.Rows[1:] {
+		if row.Currency != currency {
+			return "", false
+		}
+	}
+
+	return currency, true
+}
+
+// MonthTotal returns the income of every ticker in the month at the index.
+func (c IncomeCalendar) MonthTotal(month int) float64 {
+	var sum float64
+	for _, row := range c.Rows {
+		sum += row.Amounts[month]
+	}
+
+	return sum
+}
+
+// Total returns the income of every ticker over every month of the calendar.
+func (c IncomeCalendar) Total() float64 {
+	var sum float64
+	for _, row := range c.Rows {
+		sum += row.Total()
+	}
+
+	return sum
+}
@@ -0,0 +1,117 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type IncomeCalendarTestSuite struct {
+	suite.Suite
+
+	portfolio entities.Portfolio
+}
+
+func (suite *IncomeCalendarTestSuite) SetupTest() {
+	schd := entities.NewETF("SCHD")
+	schd.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2025, time.March, 26), Amount: 0.25},
+		{PaymentDate: date(2025, time.June, 25), Amount: 0.25},
+	})
+
+	jepi := entities.NewETF("JEPI")
+	jepi.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2025, time.May, 5), Amount: 0.5},
+		{PaymentDate: date(2025, time.June, 4), Amount: 0.5},
+	})
+
+	suite.portfolio = entities.NewPortfolio([]entities.Holding{
+		{Ticker: "SCHD", Account: "IRA", Shares: 100},
+		{Ticker: "JEPI", Account: "Taxable", Shares: 30},
+		{Ticker: "SCHD", Account: "Taxable", Shares: 20},
+	}, []*entities.ETF{schd, jepi})
+}
+
+func (suite *IncomeCalendarTestSuite) TestNewIncomeCalendar() {
+	suite.Run("should project the income of every ticker in each month from its payout frequency", func() {
+		// given
+		start := date(2025, time.July, 15)
+
+		// when
+		result := entities.NewIncomeCalendar(suite.portfolio, start, 12)
+
+		// then
+		suite.Len(result.Months, 12)
+		suite.Equal(date(2025, time.July, 1), result.Months[0])
+		suite.Equal(date(2026, time.June, 1), result.Months[11])
+		suite.Require().Len(result.Rows, 2)
+		suite.Equal("SCHD", result.Rows[0].Ticker)
+		suite.Equal([]float64{0, 0, 30, 0, 0, 30, 0, 0, 30, 0, 0, 30}, result.Rows[0].Amounts)
+		suite.Equal("JEPI", result.Rows[1].Ticker)
+		suite.InDelta(180.0, result.Rows[1].Total(), 0.001)
+		suite.InDelta(45.0, result.MonthTotal(2), 0.001)
+		suite.InDelta(15.0, result.MonthTotal(3), 0.001)
+		suite.InDelta(300.0, result.Total(), 0.001)
+
+		currency, sameCurrency := result.Currency()
+		suite.True(sameCurrency)
+		suite.Equal(entities.CurrencyUSD, currency)
+	})
+}
+
+func (suite *IncomeCalendarTestSuite) TestProjectDividends() {
+	suite.Run("should keep the declared payments and project the next ones from the latest", func() {
+		// given
+		etf := entities.NewETF("JEPI")
+		etf.SetDividends([]entities.Dividend{
+			{ExDate: date(2025, time.November, 28), PaymentDate: date(2025, time.December, 31), Amount: 0.4},
+			{ExDate: date(2025, time.December, 30), PaymentDate: date(2026, time.January, 31), Amount: 0.5},
+		})
+
+		// when
+		result := etf.ProjectDividends(date(2026, time.January, 1), date(2026, time.April, 1))
+
+		// then
+		suite.Equal([]entities.Dividend{
+			{ExDate: date(2025, time.December, 30), PaymentDate: date(2026, time.January, 31), Amount: 0.5},
+			{ExDate: date(2026, time.January, 30), PaymentDate: date(2026, time.February, 28), Amount: 0.5},
+			{ExDate: date(2026, time.February, 28), PaymentDate: date(2026, time.March, 31), Amount: 0.5},
+		}, result)
+	})
+
+	suite.Run("should leave the special distributions out of the projection", func() {
+		// given
+		etf := entities.NewETF("SCHD")
+		etf.SetDividends([]entities.Dividend{
+			{PaymentDate: date(2025, time.March, 26), Amount: 0.25},
+			{PaymentDate: date(2025, time.June, 25), Amount: 0.25},
+			{PaymentDate: date(2025, time.September, 24), Amount: 0.25},
+			{PaymentDate: date(2025, time.December, 17), Amount: 0.25},
+			{PaymentDate: date(2025, time.December, 24), Amount: 1.5},
+		})
+
+		// when
+		result := etf.ProjectDividends(date(2026, time.January, 1), date(2026, time.April, 1))
+
+		// then
+		suite.Equal([]entities.Dividend{{PaymentDate: date(2026, time.March, 17), Amount: 0.25}}, result)
+	})
+
+	suite.Run("should not project the payments of an ETF without a payout frequency", func() {
+		// given
+		etf := entities.NewETF("NEW")
+		etf.SetDividends([]entities.Dividend{{PaymentDate: date(2025, time.June, 25), Amount: 0.25}})
+
+		// when
+		result := etf.ProjectDividends(date(2025, time.July, 1), date(2026, time.July, 1))
+
+		// then
+		suite.Empty(result)
+	})
+}
+
+func TestIncomeCalendarTestSuite(t *testing.T) {
+	suite.Run(t, new(IncomeCalendarTestSuite))
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Market identifies the exchange an ETF is listed on.
+type Market string
+
+const (
+	// MarketUS groups the ETFs listed on the American exchanges.
+	MarketUS Market = "US"
+
+	// MarketB3 groups the ETFs and real-estate funds (FIIs) listed on the Brazilian exchange.
+	MarketB3 Market = "B3"
+
+	// CurrencyUSD is the ISO 4217 code of the American dollar.
+	CurrencyUSD = "USD"
+
+	// CurrencyBRL is the ISO 4217 code of the Brazilian real.
+	CurrencyBRL = "BRL"
+)
+
+// b3TickerPattern matches the B3 tickers: four letters followed by the share class, such as BOVA11 or MXRF11.
+var b3TickerPattern = regexp.MustCompile(`^[A-Z]{4}[0-9]{1,2}$`)
+
+// MarketOf returns the market of the ticker, telling the B3 tickers apart by their trailing share class number.
+func MarketOf(ticker string) Market {
+	if b3TickerPattern.MatchString(ticker) {
+		return MarketB3
+	}
+
+	return MarketUS
+}
+
+// Currency returns the ISO 4217 code of the currency the market trades and pays in.
+func (m Market) Currency() string {
+	if m == MarketB3 {
+		return CurrencyBRL
+	}
+
+	return CurrencyUSD
+}
+
+// CurrencySymbol returns the symbol shown before the amounts in the given currency.
+func CurrencySymbol(currency string) string {
+	switch currency {
+	case "", CurrencyUSD:
+		return "$"
+	case CurrencyBRL:
+		return "R$"
+	default:
+		return currency + " "
+	}
+}
+
+// NewETF creates the ETF of the ticker, with the market and the currency the ticker implies.
+func NewETF(name string) *ETF {
+	market := MarketOf(name)
+
+	return &ETF{Name: name, Market: market, Currency: market.Currency()}
+}
+
+// FormatAmount formats a monetary value in the currency of the ETF for table display.
+func (e *ETF) FormatAmount(value float64) string {
+	return fmt.Sprintf("%s%.3f", CurrencySymbol(e.Currency), value)
+}
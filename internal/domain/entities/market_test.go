@@ -0,0 +1,47 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type MarketTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MarketTestSuite) TestNewETF() {
+	suite.Run("should tell the B3 funds apart from the US ETFs by the ticker", func() {
+		// given
+		tickers := []string{"MXRF11", "BOVA11", "SPY", "SCHD", "GLD"}
+
+		// when
+		var markets []entities.Market
+		for _, ticker := range tickers {
+			markets = append(markets, entities.NewETF(ticker).Market)
+		}
+
+		// then
+		suite.Equal([]entities.Market{
+			entities.MarketB3, entities.MarketB3, entities.MarketUS, entities.MarketUS, entities.MarketUS,
+		}, markets)
+	})
+
+	suite.Run("should format the amounts in the currency of the market", func() {
+		// given
+		fii, etf := entities.NewETF("MXRF11"), entities.NewETF("SPY")
+
+		// when
+		fiiAmount, etfAmount := fii.FormatAmount(0.1), etf.FormatAmount(1.5)
+
+		// then
+		suite.Equal(entities.CurrencyBRL, fii.Currency)
+		suite.Equal("R$0.100", fiiAmount)
+		suite.Equal("$1.500", etfAmount)
+	})
+}
+
+func TestMarketTestSuite(t *testing.T) {
+	suite.Run(t, new(MarketTestSuite))
+}
@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PayoutFrequency identifies the cadence of the regular payments of an ETF.
+type PayoutFrequency string
+
+const (
+	// PayoutFrequencyUnknown is the cadence of the ETFs without enough regular payments to detect it.
+	PayoutFrequencyUnknown PayoutFrequency = ""
+
+	// PayoutFrequencyWeekly is the cadence of the ETFs paying every week.
+	PayoutFrequencyWeekly PayoutFrequency = "Weekly"
+
+	// PayoutFrequencyMonthly is the cadence of the ETFs paying every month.
+	PayoutFrequencyMonthly PayoutFrequency = "Monthly"
+
+	// PayoutFrequencyQuarterly is the cadence of the ETFs paying every quarter.
+	PayoutFrequencyQuarterly PayoutFrequency = "Quarterly"
+
+	// PayoutFrequencySemiannual is the cadence of the ETFs paying twice a year.
+	PayoutFrequencySemiannual PayoutFrequency = "Semiannual"
+
+	// PayoutFrequencyAnnual is the cadence of the ETFs paying once a year.
+	PayoutFrequencyAnnual PayoutFrequency = "Annual"
+
+	// hoursInDay converts durations between payments into days.
+	hoursInDay = 24
+
+	// daysInYear converts the payments per year of a cadence into the expected gap between payments.
+	daysInYear = 365
+
+	// weeklyMaximumGapDays is the longest median gap, in days, between payments of a weekly payer.
+	weeklyMaximumGapDays = 10
+
+	// monthlyMaximumGapDays is the longest median gap, in days, between payments of a monthly payer.
+	monthlyMaximumGapDays = 45
+
+	// quarterlyMaximumGapDays is the longest median gap, in days, between payments of a quarterly payer.
+	quarterlyMaximumGapDays = 135
+
+	// semiannualMaximumGapDays is the longest median gap, in days, between payments of a semiannual payer.
+	semiannualMaximumGapDays = 270
+
+	// weeksInYear is the number of payments per year of a weekly payer.
+	weeksInYear = 52
+
+	// monthsInYear is the number of payments per year of a monthly payer.
+	monthsInYear = 12
+
+	// quartersInYear is the number of payments per year of a quarterly payer.
+	quartersInYear = 4
+
+	// semestersInYear is the number of payments per year of a semiannual payer.
+	semestersInYear = 2
+
+	// offCadenceGapRatio is the share of the expected gap below which a payment comes too soon after the previous
+	// regular one to belong to the cadence.
+	offCadenceGapRatio = 0.5
+)
+
+// PaymentsPerYear returns how many regular payments the cadence makes per year, or zero when it is unknown.
+func (f PayoutFrequency) PaymentsPerYear() int {
+	switch f {
+	case PayoutFrequencyWeekly:
+		return weeksInYear
+	case PayoutFrequencyMonthly:
+		return monthsInYear
+	case PayoutFrequencyQuarterly:
+		return quartersInYear
+	case PayoutFrequencySemiannual:
+		return semestersInYear
+	case PayoutFrequencyAnnual:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ClassifyDividends detects the payout frequency from the median gap between the sorted payments, and flags as
+// special the payments that come too soon after the previous regular one, such as a year-end capital gains
+// distribution. Of the two payments too close together, the one whose amount is further from the median amount
+// is the special one. The frequency is then detected again from the regular payments alone.
+func ClassifyDividends(dividends []Dividend) PayoutFrequency {
+	for i := range dividends {
+		dividends[i].Special = false
+	}
+
+	frequency := frequencyOf(dividends)
+	if frequency == PayoutFrequencyUnknown {
+		return frequency
+	}
+
+	expectedGap := float64(daysInYear) / float64(frequency.PaymentsPerYear())
+	medianAmount := medianAmountOf(dividends)
+
+	previous := 0
+	for i := 1; i < len(dividends); i++ {
+		if gapInDays(dividends[previous], dividends[i]) >= expectedGap*offCadenceGapRatio {
+			previous = i
+
+			continue
+		}
+
+		special := i
+		if math.Abs(dividends[previous].Amount-medianAmount) > math.Abs(dividends[i].Amount-medianAmount) {
+			special, previous = previous, i
+		}
+
+		dividends[special].Special = true
+	}
+
+	if refined := frequencyOf(regularOf(dividends)); refined != PayoutFrequencyUnknown {
+		return refined
+	}
+
+	return frequency
+}
+
+// frequencyOf detects the payout frequency from the median gap between the sorted payments. It returns the
+// unknown frequency when there are not enough payments to detect the cadence.
+func frequencyOf(dividends []Dividend) PayoutFrequency {
+	if len(dividends) < 2 {
+		return PayoutFrequencyUnknown
+	}
+
+	gaps := make([]float64, 0, len(dividends)-1)
+	for i := 1; i < len(dividends); i++ {
+		gaps = append(gaps, gapInDays(dividends[i-1], dividends[i]))
+	}
+
+	sort.Float64s(gaps)
+	median := gaps[len(gaps)/2]
+
+	switch {
+	case median <= weeklyMaximumGapDays:
+		return PayoutFrequencyWeekly
+	case median <= monthlyMaximumGapDays:
+		return PayoutFrequencyMonthly
+	case median <= quarterlyMaximumGapDays:
+		return PayoutFrequencyQuarterly
+	case median <= semiannualMaximumGapDays:
+		return PayoutFrequencySemiannual
+	default:
+		return PayoutFrequencyAnnual
+	}
+}
+
+// gapInDays returns how many days passed between the dates of two payments.
+func gapInDays(previous, next Dividend) float64 {
+	return next.Date().Sub(previous.Date()).Hours() / hoursInDay
+}
+
+// medianAmountOf returns the median amount of the payments.
+func medianAmountOf(dividends []Dividend) float64 {
+	amounts := make([]float64, 0, len(dividends))
+	for _, dividend := range dividends {
+		amounts = append(amounts, dividend.Amount)
+	}
+
+	sort.Float64s(amounts)
+
+	return amounts[len(amounts)/2]
+}
+
+// regularOf returns the payments not flagged as special.
+func regularOf(dividends []Dividend) []Dividend {
+	regular := make([]Dividend, 0, len(dividends))
+
+	for _, dividend := range dividends {
+		if !dividend.Special {
+			regular = append(regular, dividend)
+		}
+	}
+
+	return regular
+}
+
+// RegularDividends returns the payments that follow the payout frequency, from the oldest to the most recent.
+func (e *ETF) RegularDividends() []Dividend {
+	return regularOf(e.Dividends)
+}
+
+// LastRegularDividend returns the most recent payment that follows the payout frequency.
+func (e *ETF) LastRegularDividend() (Dividend, bool) {
+	for i := len(e.Dividends) - 1; i >= 0; i-- {
+		if !e.Dividends[i].Special {
+			return e.Dividends[i], true
+		}
+	}
+
+	return Dividend{}, false
+}
+
+// SpecialDividends returns the payments that break the payout frequency, from the oldest to the most recent.
+func (e *ETF) SpecialDividends() []Dividend {
+	var special []Dividend
+
+	for _, dividend := range e.Dividends {
+		if dividend.Special {
+			special = append(special, dividend)
+		}
+	}
+
+	return special
+}
+
+// PayoutFrequencyName returns the detected payout frequency, falling back to the one the provider reported.
+func (e *ETF) PayoutFrequencyName() string {
+	if e.Frequency != PayoutFrequencyUnknown {
+		return string(e.Frequency)
+	}
+
+	return e.PayoutFrequency
+}
+
+// ShowPayoutFrequency formats the payout frequency for table display, with the count of special payments.
+func (e *ETF) ShowPayoutFrequency() string {
+	name := e.PayoutFrequencyName()
+	if name == "" {
+		return "-"
+	}
+
+	switch count := len(e.SpecialDividends()); count {
+	case 0:
+		return name
+	case 1:
+		return name + " (1 special)"
+	default:
+		return fmt.Sprintf("%s (%d specials)", name, count)
+	}
+}
@@ -0,0 +1,123 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayoutFrequencyTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *PayoutFrequencyTestSuite) SetupTest() {
+	suite.etf = entities.NewETF("VIG")
+	suite.etf.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2024, time.March, 28), Amount: 1.0},
+		{PaymentDate: date(2024, time.June, 27), Amount: 1.1},
+		{PaymentDate: date(2024, time.September, 26), Amount: 1.0},
+		{PaymentDate: date(2024, time.December, 20), Amount: 1.2},
+		{PaymentDate: date(2024, time.December, 27), Amount: 4.0},
+		{PaymentDate: date(2025, time.March, 27), Amount: 1.1},
+		{PaymentDate: date(2025, time.June, 26), Amount: 1.2},
+	})
+	suite.etf.SetPriceBars([]entities.PriceBar{{Date: date(2025, time.June, 30), Close: 100.0}})
+}
+
+func (suite *PayoutFrequencyTestSuite) TestClassifyDividends() {
+	suite.Run("should detect the cadence and flag the payment that breaks it as special", func() {
+		// given
+		// on the setup
+
+		// when
+		special := suite.etf.SpecialDividends()
+
+		// then
+		suite.Equal(entities.PayoutFrequencyQuarterly, suite.etf.Frequency)
+		suite.Len(special, 1)
+		suite.InDelta(4.0, special[0].Amount, 0.001)
+		suite.Len(suite.etf.RegularDividends(), 6)
+	})
+
+	suite.Run("should keep the payment closer to the usual amount when it comes first", func() {
+		// given
+		dividends := []entities.Dividend{
+			{PaymentDate: date(2024, time.January, 31), Amount: 0.5},
+			{PaymentDate: date(2024, time.February, 29), Amount: 0.5},
+			{PaymentDate: date(2024, time.March, 5), Amount: 2.0},
+			{PaymentDate: date(2024, time.March, 28), Amount: 0.5},
+			{PaymentDate: date(2024, time.April, 30), Amount: 0.5},
+		}
+
+		// when
+		result := entities.ClassifyDividends(dividends)
+
+		// then
+		suite.Equal(entities.PayoutFrequencyMonthly, result)
+		suite.False(dividends[1].Special)
+		suite.True(dividends[2].Special)
+	})
+
+	suite.Run("should not detect a cadence from a single payment", func() {
+		// given
+		dividends := []entities.Dividend{{PaymentDate: date(2024, time.March, 28), Amount: 1.0}}
+
+		// when
+		result := entities.ClassifyDividends(dividends)
+
+		// then
+		suite.Equal(entities.PayoutFrequencyUnknown, result)
+		suite.Zero(result.PaymentsPerYear())
+	})
+}
+
+func (suite *PayoutFrequencyTestSuite) TestForwardDividends() {
+	suite.Run("should annualize the latest regular payment instead of the special one", func() {
+		// given
+		etf := entities.NewETF("VIG")
+		etf.SetDividends(append(suite.etf.RegularDividends(),
+			entities.Dividend{PaymentDate: date(2025, time.July, 3), Amount: 5.0},
+		))
+
+		// when
+		result, exists := etf.ForwardDividends()
+
+		// then
+		suite.True(exists)
+		suite.InDelta(4.8, result, 0.001)
+		suite.Len(etf.SpecialDividends(), 1)
+	})
+}
+
+func (suite *PayoutFrequencyTestSuite) TestShowPayoutFrequency() {
+	suite.Run("should show the detected cadence with the count of special payments", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.ShowFundamentals()
+
+		// then
+		suite.Equal("Quarterly (1 special)", result[0])
+	})
+
+	suite.Run("should fall back to the frequency reported by the provider", func() {
+		// given
+		etf := entities.NewETF("VIG")
+		etf.PayoutFrequency = "Monthly"
+
+		// when
+		result := etf.ShowPayoutFrequency()
+
+		// then
+		suite.Equal("Monthly", result)
+	})
+}
+
+func TestPayoutFrequencyTestSuite(t *testing.T) {
+	suite.Run(t, new(PayoutFrequencyTestSuite))
+}
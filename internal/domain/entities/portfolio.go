@@ -0,0 +1,176 @@
+package entities
+
+// Holding represents a position of the holdings file: the shares of a ticker held in an account.
+type Holding struct {
+	Ticker    string
+	Account   string  // Brokerage account holding the shares, such as "IRA", empty when not given.
+	Shares    float64 // Number of shares, possibly fractional.
+	CostBasis float64 // Total paid for the shares, in the currency the report is shown in.
+}
+
+// Position joins a holding with the dividends and prices of its ETF.
+type Position struct {
+	Holding
+
+	ETF *ETF
+}
+
+// Portfolio represents every position of the holdings file.
+type Portfolio struct {
+	Positions []Position
+}
+
+// NewPortfolio joins every holding with the ETF of its ticker. The holdings whose ETF is missing get an ETF
+// without any data, so they still count towards the cost basis.
+func NewPortfolio(holdings []Holding, etfs []*ETF) Portfolio {
+	byName := make(map[string]*ETF, len(etfs))
+	for _, etf := range etfs {
+		byName[etf.Name] = etf
+	}
+
+	portfolio := Portfolio{Positions: make([]Position, 0, len(holdings))}
+
+	for _, holding := range holdings {
+		etf, exists := byName[holding.Ticker]
+		if !exists {
+			etf = NewETF(holding.Ticker)
+		}
+
+		portfolio.Positions = append(portfolio.Positions, Position{Holding: holding, ETF: etf})
+	}
+
+	return portfolio
+}
+
+// Tickers returns the distinct tickers of the holdings, in the order they first appear.
+func Tickers(holdings []Holding) []string {
+	var tickers []string
+
+	seen := make(map[string]bool, len(holdings))
+	for _, holding := range holdings {
+		if !seen[holding.Ticker] {
+			seen[holding.Ticker] = true
+			tickers = append(tickers, holding.Ticker)
+		}
+	}
+
+	return tickers
+}
+
+// MarketValue returns the value of the shares at the latest close.
+func (p Position) MarketValue() (float64, bool) {
+	bar, exists := p.ETF.LastPriceBar()
+	if !exists {
+		return 0, false
+	}
+
+	return p.Shares * bar.Close, true
+}
+
+// AnnualIncome projects the income of the next twelve months from the forward dividends of the ETF, which leave the
+// special distributions out.
+func (p Position) AnnualIncome() (float64, bool) {
+	forwardDividends, exists := p.ETF.ForwardDividends()
+	if !exists {
+		return 0, false
+	}
+
+	return p.Shares * forwardDividends, true
+}
+
+// MonthlyIncome spreads the projected annual income evenly over the months.
+func (p Position) MonthlyIncome() (float64, bool) {
+	income, exists := p.AnnualIncome()
+
+	return income / monthsInYear, exists
+}
+
+// YieldOnCost returns the projected annual income as a percentage of the cost basis.
+func (p Position) YieldOnCost() (float64, bool) {
+	income, exists := p.AnnualIncome()
+	if !exists || p.CostBasis == 0 {
+		return 0, false
+	}
+
+	return income / p.CostBasis * PercentageMultiplier, true
+}
+
+// Currency returns the currency shared by every position, or false when they are in different currencies and their
+// amounts cannot be added up.
+func (p Portfolio) Currency() (string, bool) {
+	if len(p.Positions) == 0 {
+		return "", false
+	}
+
+	currency := p.Positions[0].ETF.Currency
+	for _, position := range p.Positions[1:] {
+		if position.ETF.Currency != currency {
+			return "", false
+		}
+	}
+
+	return currency, true
+}
+
+// CostBasis sums the cost basis of every position.
+func (p Portfolio) CostBasis() float64 {
+	var sum float64
+	for _, position := range p.Positions {
+		sum += position.CostBasis
+	}
+
+	return sum
+}
+
+// MarketValue sums the market value of the positions with a latest close.
+func (p Portfolio) MarketValue() float64 {
+	var sum float64
+
+	for _, position := range p.Positions {
+		if value, exists := position.MarketValue(); exists {
+			sum += value
+		}
+	}
+
+	return sum
+}
+
+// AnnualIncome sums the projected annual income of the positions with forward dividends.
+func (p Portfolio) AnnualIncome() float64 {
+	var sum float64
+
+	for _, position := range p.Positions {
+		if income, exists := position.AnnualIncome(); exists {
+			sum += income
+		}
+	}
+
+	return sum
+}
+
+// MonthlyIncome spreads the projected annual income of the portfolio evenly over the months.
+func (p Portfolio) MonthlyIncome() float64 {
+	return p.AnnualIncome() / monthsInYear
+}
+
+// YieldOnCost returns the projected annual income of the portfolio as a percentage of its cost basis.
+func (p Portfolio) YieldOnCost() (float64, bool) {
+	costBasis := p.CostBasis()
+	if costBasis == 0 {
+		return 0, false
+	}
+
+	return p.AnnualIncome() / costBasis * PercentageMultiplier, true
+}
+
+// Weight returns the share of the market value of the portfolio held in the position, as a percentage.
+func (p Portfolio) Weight(position Position) (float64, bool) {
+	value, exists := position.MarketValue()
+	total := p.MarketValue()
+
+	if !exists || total == 0 {
+		return 0, false
+	}
+
+	return value / total * PercentageMultiplier, true
+}
@@ -0,0 +1,118 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type PortfolioTestSuite struct {
+	suite.Suite
+
+	portfolio entities.Portfolio
+}
+
+func (suite *PortfolioTestSuite) SetupTest() {
+	schd := entities.NewETF("SCHD")
+	schd.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2025, time.March, 26), Amount: 0.25},
+		{PaymentDate: date(2025, time.June, 25), Amount: 0.25},
+	})
+	schd.SetPriceBars([]entities.PriceBar{{Date: date(2025, time.June, 30), Close: 25}})
+
+	jepi := entities.NewETF("JEPI")
+	jepi.SetDividends([]entities.Dividend{
+		{PaymentDate: date(2025, time.May, 5), Amount: 0.5},
+		{PaymentDate: date(2025, time.June, 4), Amount: 0.5},
+	})
+	jepi.SetPriceBars([]entities.PriceBar{{Date: date(2025, time.June, 30), Close: 50}})
+
+	suite.portfolio = entities.NewPortfolio([]entities.Holding{
+		{Ticker: "SCHD", Account: "IRA", Shares: 100, CostBasis: 2000},
+		{Ticker: "JEPI", Account: "Taxable", Shares: 30, CostBasis: 1500},
+		{Ticker: "SCHD", Account: "Taxable", Shares: 20, CostBasis: 500},
+	}, []*entities.ETF{schd, jepi})
+}
+
+func (suite *PortfolioTestSuite) TestPosition() {
+	suite.Run("should value the shares and project their income from the forward dividends", func() {
+		// given
+		position := suite.portfolio.Positions[0]
+
+		// when
+		value, valueExists := position.MarketValue()
+		income, incomeExists := position.AnnualIncome()
+		monthly, _ := position.MonthlyIncome()
+		yieldOnCost, _ := position.YieldOnCost()
+
+		// then
+		suite.True(valueExists)
+		suite.InDelta(2500.0, value, 0.001)
+		suite.True(incomeExists)
+		suite.InDelta(100.0, income, 0.001)
+		suite.InDelta(8.333, monthly, 0.001)
+		suite.InDelta(5.0, yieldOnCost, 0.001)
+	})
+
+	suite.Run("should not value a position without prices", func() {
+		// given
+		portfolio := entities.NewPortfolio([]entities.Holding{{Ticker: "NEW", Shares: 10, CostBasis: 100}}, nil)
+
+		// when
+		_, exists := portfolio.Positions[0].MarketValue()
+
+		// then
+		suite.False(exists)
+		suite.InDelta(100.0, portfolio.CostBasis(), 0.001)
+	})
+}
+
+func (suite *PortfolioTestSuite) TestPortfolio() {
+	suite.Run("should add up the positions and weigh each one by its market value", func() {
+		// given
+		// on the setup
+
+		// when
+		weight, exists := suite.portfolio.Weight(suite.portfolio.Positions[1])
+		yieldOnCost, _ := suite.portfolio.YieldOnCost()
+		currency, sameCurrency := suite.portfolio.Currency()
+
+		// then
+		suite.InDelta(4500.0, suite.portfolio.MarketValue(), 0.001)
+		suite.InDelta(300.0, suite.portfolio.AnnualIncome(), 0.001)
+		suite.InDelta(25.0, suite.portfolio.MonthlyIncome(), 0.001)
+		suite.True(exists)
+		suite.InDelta(33.333, weight, 0.001)
+		suite.InDelta(7.5, yieldOnCost, 0.001)
+		suite.True(sameCurrency)
+		suite.Equal(entities.CurrencyUSD, currency)
+	})
+
+	suite.Run("should not add up the positions in different currencies", func() {
+		// given
+		portfolio := entities.NewPortfolio([]entities.Holding{{Ticker: "SCHD"}, {Ticker: "MXRF11"}}, nil)
+
+		// when
+		_, sameCurrency := portfolio.Currency()
+
+		// then
+		suite.False(sameCurrency)
+	})
+
+	suite.Run("should list every ticker once", func() {
+		// given
+		holdings := []entities.Holding{{Ticker: "SCHD"}, {Ticker: "JEPI"}, {Ticker: "SCHD"}}
+
+		// when
+		result := entities.Tickers(holdings)
+
+		// then
+		suite.Equal([]string{"SCHD", "JEPI"}, result)
+	})
+}
+
+func TestPortfolioTestSuite(t *testing.T) {
+	suite.Run(t, new(PortfolioTestSuite))
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// PriceBar represents the trading activity of an ETF during a single day.
+type PriceBar struct {
+	Date          time.Time
+	Open          float64
+	High          float64
+	Low           float64
+	Close         float64
+	Volume        float64 // Number of shares traded.
+	Source        string  // Name of the provider that supplied the bar, when recorded.
+	SplitAdjusted bool    // Whether the prices and the volume are already in the share terms after every split.
+}
+
+// Year returns the year of the bar, as used for the yearly keys of the ETF maps.
+func (b PriceBar) Year() string {
+	return strconv.Itoa(b.Date.Year())
+}
+
+// SortPriceBars sorts the bars in place from the oldest to the most recent.
+func SortPriceBars(bars []PriceBar) {
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].Date.Before(bars[j].Date)
+	})
+}
+
+// AverageClosingPricePerYear averages the closing prices of the bars by year.
+func AverageClosingPricePerYear(bars []PriceBar) map[string]float64 {
+	averageClosePrices := make(map[string]float64)
+	yearlySums := make(map[string]float64)
+	yearlyCounts := make(map[string]int)
+
+	for _, bar := range bars {
+		year := bar.Year()
+		yearlySums[year] += bar.Close
+		yearlyCounts[year]++
+	}
+
+	for year, sum := range yearlySums {
+		if count, exists := yearlyCounts[year]; exists && count > 0 {
+			averageClosePrices[year] = sum / float64(count)
+		}
+	}
+
+	return averageClosePrices
+}
+
+// YearEndClosingPricePerYear returns the closing price of the last bar of each year.
+// The bars must be sorted from the oldest to the most recent.
+func YearEndClosingPricePerYear(bars []PriceBar) map[string]float64 {
+	yearEndPrices := make(map[string]float64)
+
+	for _, bar := range bars {
+		yearEndPrices[bar.Year()] = bar.Close
+	}
+
+	return yearEndPrices
+}
+
+// PriceSourcePerYear returns the providers that supplied the bars of each year.
+func PriceSourcePerYear(bars []PriceBar) map[string]string {
+	sources := make(map[string][]string)
+
+	for _, bar := range bars {
+		if bar.Source == "" {
+			continue
+		}
+
+		sources[bar.Year()] = append(sources[bar.Year()], bar.Source)
+	}
+
+	return joinSources(sources)
+}
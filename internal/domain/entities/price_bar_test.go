@@ -0,0 +1,70 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type PriceBarTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *PriceBarTestSuite) SetupTest() {
+	suite.etf = &entities.ETF{Name: "TestETF"}
+	suite.etf.SetPriceBars([]entities.PriceBar{
+		{Date: date(2024, time.January, 2), Close: 110.0},
+		{Date: date(2023, time.December, 29), Close: 100.0},
+		{Date: date(2024, time.January, 3), Close: 130.0},
+		{Date: date(2023, time.December, 28), Close: 90.0},
+	})
+}
+
+func (suite *PriceBarTestSuite) TestSetPriceBars() {
+	suite.Run("should sort the bars by date and average the closing prices by year", func() {
+		// given
+		// on the setup
+
+		// when
+		result := suite.etf.AverageClosingPricePerYear
+
+		// then
+		suite.Equal(date(2023, time.December, 28), suite.etf.PriceBars[0].Date)
+		suite.Equal(map[string]float64{"2023": 95.0, "2024": 120.0}, result)
+	})
+}
+
+func (suite *PriceBarTestSuite) TestYearEndClosingPricePerYear() {
+	suite.Run("should return the closing price of the last bar of each year", func() {
+		// given
+		// on the setup
+
+		// when
+		result := entities.YearEndClosingPricePerYear(suite.etf.PriceBars)
+
+		// then
+		suite.Equal(map[string]float64{"2023": 100.0, "2024": 130.0}, result)
+	})
+}
+
+func (suite *PriceBarTestSuite) TestLastPriceBar() {
+	suite.Run("should return the most recent trading day", func() {
+		// given
+		// on the setup
+
+		// when
+		result, exists := suite.etf.LastPriceBar()
+
+		// then
+		suite.True(exists)
+		suite.InDelta(130.0, result.Close, 0.001)
+	})
+}
+
+func TestPriceBarTestSuite(t *testing.T) {
+	suite.Run(t, new(PriceBarTestSuite))
+}
@@ -0,0 +1,204 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// paymentMatchWindowDays is how many days apart the providers may date the same payment.
+const paymentMatchWindowDays = 5
+
+// ReconciledAmounts holds what each provider reported for the same year or payment.
+type ReconciledAmounts struct {
+	Amounts map[string]float64 // Key: Provider Name, Value: Amount.
+	Missing []string           // Providers whose history covers the period but that did not report it.
+}
+
+// SpreadPercentage returns how much the largest reported amount exceeds the smallest one, relative to the largest.
+func (r ReconciledAmounts) SpreadPercentage() float64 {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, amount := range r.Amounts {
+		lowest, highest = min(lowest, amount), max(highest, amount)
+	}
+
+	if len(r.Amounts) < 2 || highest <= 0 {
+		return 0
+	}
+
+	return (highest - lowest) / highest * PercentageMultiplier
+}
+
+// IsDiscrepant reports whether a provider missed the amount or the amounts differ by more than the tolerance.
+func (r ReconciledAmounts) IsDiscrepant(tolerancePercentage float64) bool {
+	return len(r.Missing) > 0 || r.SpreadPercentage() > tolerancePercentage
+}
+
+// ReconciledYear aligns the yearly dividend sums of every provider.
+type ReconciledYear struct {
+	ReconciledAmounts
+
+	Year string
+}
+
+// ReconciledPayment aligns the amounts the providers reported for the same payment.
+type ReconciledPayment struct {
+	ReconciledAmounts
+
+	Date time.Time // Earliest date any provider reported for the payment, preferring the ex-date.
+}
+
+// Reconciliation aligns the dividends of an ETF reported by several providers.
+type Reconciliation struct {
+	Providers []string
+	Years     []ReconciledYear    // From the most recent year backwards.
+	Payments  []ReconciledPayment // From the most recent payment backwards.
+}
+
+// ReconcileDividends aligns the dividends the providers reported since the first year, per year and per payment.
+// Every provider is bucketed by the ex-date, falling back to the payment date, because some providers only report
+// one of them and a December ex-date is often paid in January. The payments are matched when their dates are a few
+// days apart, so providers that disagree on the exact date are still compared. A provider only counts as missing a
+// year or payment inside the span of its own history.
+func ReconcileDividends(providers []string, dividends map[string][]Dividend, firstYear int) Reconciliation {
+	reconciliation := Reconciliation{Providers: providers}
+
+	var lastYear int
+
+	spans := make(map[string][2]time.Time)
+	for _, provider := range providers {
+		var first, last time.Time
+		for _, dividend := range dividends[provider] {
+			date := exDateOf(dividend)
+			if first.IsZero() || date.Before(first) {
+				first = date
+			}
+			if date.After(last) {
+				last = date
+			}
+			lastYear = max(lastYear, date.Year())
+		}
+		spans[provider] = [2]time.Time{first, last}
+	}
+
+	yearlySums := make(map[string]map[string]float64)
+	for _, provider := range providers {
+		yearlySums[provider] = sumPerExDateYear(dividends[provider])
+	}
+
+	for year := lastYear; year >= firstYear; year-- {
+		key := strconv.Itoa(year)
+		reconciled := ReconciledYear{Year: key, ReconciledAmounts: ReconciledAmounts{Amounts: make(map[string]float64)}}
+
+		for _, provider := range providers {
+			if sum, exists := yearlySums[provider][key]; exists {
+				reconciled.Amounts[provider] = sum
+			} else if span := spans[provider]; !span[0].IsZero() && span[0].Year() <= year && year <= span[1].Year() {
+				reconciled.Missing = append(reconciled.Missing, provider)
+			}
+		}
+
+		if len(reconciled.Amounts) > 0 || len(reconciled.Missing) > 0 {
+			reconciliation.Years = append(reconciliation.Years, reconciled)
+		}
+	}
+
+	for _, payment := range matchPayments(providers, dividends, firstYear) {
+		for _, provider := range providers {
+			span := spans[provider]
+			if _, exists := payment.Amounts[provider]; exists || span[0].IsZero() {
+				continue
+			}
+
+			window := paymentMatchWindowDays * hoursInDay * time.Hour
+			if !payment.Date.Before(span[0].Add(-window)) && !payment.Date.After(span[1].Add(window)) {
+				payment.Missing = append(payment.Missing, provider)
+			}
+		}
+
+		reconciliation.Payments = append(reconciliation.Payments, payment)
+	}
+
+	return reconciliation
+}
+
+// matchPayments groups the payments of every provider made since the first year. A payment joins the group of the
+// same period that does not have one from its provider yet, choosing the closest amount when there are several,
+// so a special distribution paid next to a regular one is matched to the right counterpart.
+func matchPayments(providers []string, dividends map[string][]Dividend, firstYear int) []ReconciledPayment {
+	type reported struct {
+		provider string
+		dividend Dividend
+	}
+
+	var all []reported
+	for _, provider := range providers {
+		for _, dividend := range dividends[provider] {
+			if date := exDateOf(dividend); !date.IsZero() && date.Year() >= firstYear {
+				all = append(all, reported{provider: provider, dividend: dividend})
+			}
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return exDateOf(all[i].dividend).Before(exDateOf(all[j].dividend))
+	})
+
+	window := paymentMatchWindowDays * hoursInDay * time.Hour
+
+	var payments []ReconciledPayment
+	for _, entry := range all {
+		date := exDateOf(entry.dividend)
+		best := -1
+
+		for index := len(payments) - 1; index >= 0 && date.Sub(payments[index].Date) <= window; index-- {
+			if _, exists := payments[index].Amounts[entry.provider]; exists {
+				continue
+			}
+
+			if best == -1 || amountDistance(payments[index], entry.dividend.Amount) <
+				amountDistance(payments[best], entry.dividend.Amount) {
+				best = index
+			}
+		}
+
+		if best == -1 {
+			payments = append(payments, ReconciledPayment{
+				Date:              date,
+				ReconciledAmounts: ReconciledAmounts{Amounts: make(map[string]float64)},
+			})
+			best = len(payments) - 1
+		}
+
+		payments[best].Amounts[entry.provider] = entry.dividend.Amount
+	}
+
+	slices.Reverse(payments)
+
+	return payments
+}
+
+// sumPerExDateYear sums the dividend amounts by the year of their ex-date, or payment date when it is missing.
+func sumPerExDateYear(dividends []Dividend) map[string]float64 {
+	yearlySums := make(map[string]float64)
+
+	for _, dividend := range dividends {
+		if date := exDateOf(dividend); !date.IsZero() {
+			yearlySums[strconv.Itoa(date.Year())] += dividend.Amount
+		}
+	}
+
+	return yearlySums
+}
+
+// amountDistance returns how far the amount is from the closest amount already in the payment.
+func amountDistance(payment ReconciledPayment, amount float64) float64 {
+	distance := math.Inf(1)
+	for _, reported := range payment.Amounts {
+		distance = min(distance, math.Abs(reported-amount))
+	}
+
+	return distance
+}
@@ -0,0 +1,133 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReconciliationTestSuite struct {
+	suite.Suite
+
+	dividends map[string][]entities.Dividend
+}
+
+func (suite *ReconciliationTestSuite) SetupTest() {
+	suite.dividends = map[string][]entities.Dividend{
+		"nasdaq": {
+			{ExDate: date(2024, time.March, 20), Amount: 0.40},
+			{ExDate: date(2024, time.June, 20), Amount: 0.44},
+			{ExDate: date(2024, time.December, 16), Amount: 0.50},
+			{ExDate: date(2024, time.December, 16), Amount: 1.20}, // Special distribution.
+		},
+		"historyorg": {
+			{ExDate: date(2024, time.March, 21), Amount: 0.40},
+			{ExDate: date(2024, time.June, 20), Amount: 0.45},
+			{ExDate: date(2024, time.December, 16), Amount: 0.50},
+		},
+	}
+}
+
+func (suite *ReconciliationTestSuite) TestReconcileDividendsPerPayment() {
+	suite.Run("should match the payments a few days apart and flag the one a provider missed", func() {
+		// given
+		// on the setup
+
+		// when
+		result := entities.ReconcileDividends([]string{"nasdaq", "historyorg"}, suite.dividends, 2024)
+
+		// then
+		suite.Require().Len(result.Payments, 4)
+		special := result.Payments[0]
+		suite.Equal(date(2024, time.December, 16), special.Date)
+		suite.Equal([]string{"historyorg"}, special.Missing)
+		suite.InDelta(1.20, special.Amounts["nasdaq"], 0.001)
+		suite.False(result.Payments[1].IsDiscrepant(1))
+		suite.InDelta(0.50, result.Payments[1].Amounts["historyorg"], 0.001)
+		suite.True(result.Payments[2].IsDiscrepant(1))
+		suite.False(result.Payments[3].IsDiscrepant(1))
+	})
+}
+
+func (suite *ReconciliationTestSuite) TestReconcileDividendsPerYear() {
+	suite.Run("should compare the yearly sums against the tolerance", func() {
+		// given
+		// on the setup
+
+		// when
+		result := entities.ReconcileDividends([]string{"nasdaq", "historyorg"}, suite.dividends, 2024)
+
+		// then
+		suite.Require().Len(result.Years, 1)
+		suite.Equal("2024", result.Years[0].Year)
+		suite.InDelta(2.54, result.Years[0].Amounts["nasdaq"], 0.001)
+		suite.InDelta(1.35, result.Years[0].Amounts["historyorg"], 0.001)
+		suite.True(result.Years[0].IsDiscrepant(1))
+	})
+
+	suite.Run("should not count a provider without any data as missing", func() {
+		// given
+		suite.dividends["statusinvest"] = nil
+
+		// when
+		result := entities.ReconcileDividends([]string{"nasdaq", "statusinvest"}, suite.dividends, 2024)
+
+		// then
+		suite.Require().Len(result.Years, 1)
+		suite.Empty(result.Years[0].Missing)
+		suite.False(result.Years[0].IsDiscrepant(1))
+	})
+}
+
+func (suite *ReconciliationTestSuite) TestReconcileDividendsAcrossYears() {
+	suite.Run("should bucket a December ex-date paid in January in the same year for every provider", func() {
+		// given
+		dividends := map[string][]entities.Dividend{
+			"nasdaq": {
+				{ExDate: date(2023, time.June, 15), PaymentDate: date(2023, time.June, 30), Amount: 0.5},
+				{ExDate: date(2023, time.December, 15), PaymentDate: date(2024, time.January, 5), Amount: 0.6},
+				{ExDate: date(2024, time.June, 14), PaymentDate: date(2024, time.June, 28), Amount: 0.5},
+			},
+			"yahoo": {
+				{ExDate: date(2023, time.June, 15), Amount: 0.5},
+				{ExDate: date(2023, time.December, 15), Amount: 0.6},
+				{ExDate: date(2024, time.June, 14), Amount: 0.5},
+			},
+		}
+
+		// when
+		result := entities.ReconcileDividends([]string{"nasdaq", "yahoo"}, dividends, 2023)
+
+		// then
+		suite.Require().Len(result.Years, 2)
+		for _, year := range result.Years {
+			suite.False(year.IsDiscrepant(1), year.Year)
+		}
+		suite.InDelta(1.1, result.Years[1].Amounts["nasdaq"], 0.001)
+		suite.Require().Len(result.Payments, 3)
+		suite.Equal(date(2023, time.December, 15), result.Payments[1].Date)
+		suite.Empty(result.Payments[1].Missing)
+		suite.False(result.Payments[1].IsDiscrepant(1))
+	})
+
+	suite.Run("should leave out the payments with an ex-date before the first year", func() {
+		// given
+		dividends := map[string][]entities.Dividend{
+			"nasdaq": {{ExDate: date(2023, time.December, 15), PaymentDate: date(2024, time.January, 5), Amount: 0.6}},
+			"yahoo":  {{ExDate: date(2023, time.December, 15), Amount: 0.6}},
+		}
+
+		// when
+		result := entities.ReconcileDividends([]string{"nasdaq", "yahoo"}, dividends, 2024)
+
+		// then
+		suite.Empty(result.Years)
+		suite.Empty(result.Payments)
+	})
+}
+
+func TestReconciliationTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconciliationTestSuite))
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+)
+
+// sourceSeparator joins the names of the providers that supplied parts of the same year.
+const sourceSeparator = "+"
+
+// joinSources collapses the providers listed for each year into a single name, keeping each provider once.
+func joinSources(sources map[string][]string) map[string]string {
+	joined := make(map[string]string, len(sources))
+
+	for year, names := range sources {
+		slices.Sort(names)
+		joined[year] = strings.Join(slices.Compact(names), sourceSeparator)
+	}
+
+	return joined
+}
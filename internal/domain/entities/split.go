@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Split represents a change in the number of shares of an ETF, such as a 2-for-1 split or a 1-for-10 reverse split.
+type Split struct {
+	Date        time.Time // First trading day in the new share terms.
+	Numerator   float64   // Shares held after the split for each Denominator shares held before it.
+	Denominator float64
+	Source      string // Name of the provider that supplied the split, when recorded.
+}
+
+// Ratio returns the number of shares held after the split for each share held before it, or zero when the split
+// is not valid.
+func (s Split) Ratio() float64 {
+	if s.Numerator <= 0 || s.Denominator <= 0 {
+		return 0
+	}
+
+	return s.Numerator / s.Denominator
+}
+
+// SortSplits sorts the splits in place from the oldest to the most recent.
+func SortSplits(splits []Split) {
+	sort.SliceStable(splits, func(i, j int) bool {
+		return splits[i].Date.Before(splits[j].Date)
+	})
+}
+
+// splitFactor returns the number of current shares each share held on the date became, after every later split.
+func splitFactor(splits []Split, date time.Time) float64 {
+	factor := 1.0
+
+	for _, split := range splits {
+		if ratio := split.Ratio(); ratio > 0 && date.Before(split.Date) {
+			factor *= ratio
+		}
+	}
+
+	return factor
+}
+
+// AdjustForSplits normalizes the dividends and the prices recorded before each split to the current share terms,
+// so the yearly averages and yields are not distorted by the change in the number of shares. The records the
+// provider already adjusted are left untouched.
+func (e *ETF) AdjustForSplits(splits []Split) {
+	if len(splits) == 0 {
+		return
+	}
+
+	dividends := make([]Dividend, len(e.Dividends))
+	for index, dividend := range e.Dividends {
+		// The payment date may already be after the split, while the shares entitled to the payment were the old ones.
+		if !dividend.SplitAdjusted {
+			dividend.Amount /= splitFactor(splits, exDateOf(dividend))
+			dividend.SplitAdjusted = true
+		}
+
+		dividends[index] = dividend
+	}
+
+	bars := make([]PriceBar, len(e.PriceBars))
+	for index, bar := range e.PriceBars {
+		if !bar.SplitAdjusted {
+			factor := splitFactor(splits, bar.Date)
+			bar.Open, bar.High, bar.Low, bar.Close = bar.Open/factor, bar.High/factor, bar.Low/factor, bar.Close/factor
+			bar.Volume *= factor
+			bar.SplitAdjusted = true
+		}
+
+		bars[index] = bar
+	}
+
+	e.SetDividends(dividends)
+	e.SetPriceBars(bars)
+}
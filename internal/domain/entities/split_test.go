@@ -0,0 +1,80 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type SplitTestSuite struct {
+	suite.Suite
+
+	etf *entities.ETF
+}
+
+func (suite *SplitTestSuite) SetupTest() {
+	suite.etf = entities.NewETF("SVOL")
+	suite.etf.SetDividends([]entities.Dividend{
+		{ExDate: date(2023, time.June, 1), PaymentDate: date(2023, time.June, 8), Amount: 0.30},
+		{ExDate: date(2024, time.June, 3), PaymentDate: date(2024, time.June, 10), Amount: 3.00},
+	})
+	suite.etf.SetPriceBars([]entities.PriceBar{
+		{Date: date(2023, time.December, 29), Close: 20, Volume: 1000},
+		{Date: date(2024, time.January, 2), Close: 200, Volume: 100},
+		{Date: date(2024, time.January, 3), Close: 20, Volume: 1000, SplitAdjusted: true},
+	})
+}
+
+func (suite *SplitTestSuite) TestRatio() {
+	suite.Run("should tell the shares after the split for each share before it", func() {
+		// given
+		split := entities.Split{Numerator: 1, Denominator: 10}
+		invalid := entities.Split{Numerator: 2}
+
+		// when
+		ratio := split.Ratio()
+
+		// then
+		suite.InDelta(0.1, ratio, 0.0001)
+		suite.Zero(invalid.Ratio())
+	})
+}
+
+func (suite *SplitTestSuite) TestAdjustForSplits() {
+	suite.Run("should restate the records before a reverse split in the current share terms", func() {
+		// given
+		splits := []entities.Split{{Date: date(2024, time.January, 2), Numerator: 1, Denominator: 10}}
+
+		// when
+		suite.etf.AdjustForSplits(splits)
+
+		// then
+		suite.InDelta(3.00, suite.etf.AmountDividendsPerYear["2023"], 0.0001)
+		suite.InDelta(3.00, suite.etf.AmountDividendsPerYear["2024"], 0.0001)
+		suite.InDelta(200.0, suite.etf.AverageClosingPricePerYear["2023"], 0.0001)
+		suite.InDelta(100.0, suite.etf.PriceBars[0].Volume, 0.0001)
+		suite.InDelta(110.0, suite.etf.AverageClosingPricePerYear["2024"], 0.0001)
+		suite.InDelta(1.5, suite.etf.CalculateDividendYieldPerYear(2024, 2)["2023"], 0.0001)
+	})
+}
+
+func (suite *SplitTestSuite) TestAdjustForSplitsTwice() {
+	suite.Run("should not adjust the records twice", func() {
+		// given
+		splits := []entities.Split{{Date: date(2024, time.January, 2), Numerator: 2, Denominator: 1}}
+		suite.etf.AdjustForSplits(splits)
+
+		// when
+		suite.etf.AdjustForSplits(splits)
+
+		// then
+		suite.InDelta(10.0, suite.etf.PriceBars[0].Close, 0.0001)
+		suite.InDelta(0.15, suite.etf.Dividends[0].Amount, 0.0001)
+	})
+}
+
+func TestSplitTestSuite(t *testing.T) {
+	suite.Run(t, new(SplitTestSuite))
+}
@@ -1,6 +1,12 @@
 package repositories
 
-// DividendsRepository defines the interface for getting dividends per year.
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// DividendsRepository defines the interface for getting every dividend payment of an ETF.
 type DividendsRepository interface {
-	ListDividendsByETF(etf string) (map[string]float64, error)
+	ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error)
 }
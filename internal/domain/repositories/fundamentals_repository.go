@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// FundamentalsRepository defines the interface for getting the fundamentals of an ETF.
+type FundamentalsRepository interface {
+	GetFundamentalsByETF(ctx context.Context, etf string) (entities.Fundamentals, error)
+}
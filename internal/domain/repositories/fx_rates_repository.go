@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// FXRatesRepository defines the interface for getting the daily exchange rates of a currency pair in a period.
+type FXRatesRepository interface {
+	ListRatesByPair(ctx context.Context, base, quote string, from, to time.Time) ([]entities.FXRate, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// HoldingsRepository defines the interface for getting every position of the holdings file.
+type HoldingsRepository interface {
+	ListHoldings(ctx context.Context) ([]entities.Holding, error)
+}
@@ -1,6 +1,12 @@
 package repositories
 
-// PricesRepository defines the interface for getting prices per year.
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// PricesRepository defines the interface for getting the daily price history of an ETF.
 type PricesRepository interface {
-	ListClosingPricesByETF(etf string) (map[string]float64, error)
+	ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error)
 }
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// SplitsRepository defines the interface for getting every share split and reverse split of an ETF.
+type SplitsRepository interface {
+	ListSplitsByETF(ctx context.Context, etf string) ([]entities.Split, error)
+}
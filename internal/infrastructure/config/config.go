@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// appDirectory is the directory created under the user configuration directory.
+	appDirectory = "investmate"
+
+	// fileName is the name of the configuration file inside the application directory.
+	fileName = "config.yaml"
+)
+
+// ErrWatchlistNotFound is returned when the requested watchlist is not defined in the configuration file.
+var ErrWatchlistNotFound = errors.New("watchlist not found")
+
+// Watchlist is a named group of tickers with its own report settings.
+// Zero values inherit the settings defined at the top level of the configuration file.
+type Watchlist struct {
+	Tickers               []string  `yaml:"tickers"`
+	TargetYieldPercentage float64   `yaml:"target_yield"`
+	Years                 int       `yaml:"years"`
+	Provider              Providers `yaml:"provider"`
+}
+
+// Providers lists the data providers in the order they are tried. In the configuration file it is either a
+// single name or a list of names.
+type Providers []string
+
+// UnmarshalYAML accepts both a single provider name and a list of names.
+func (p *Providers) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*p = Providers{value.Value}
+		return nil
+	}
+
+	var names []string
+	if err := value.Decode(&names); err != nil {
+		return fmt.Errorf("failed to parse the providers: %w", err)
+	}
+
+	*p = names
+
+	return nil
+}
+
+// Cache holds the settings of the on-disk response cache. Zero values fall back to the built-in defaults.
+type Cache struct {
+	Directory       string        `yaml:"directory"`
+	DividendsTTL    time.Duration `yaml:"dividends_ttl"`
+	PricesTTL       time.Duration `yaml:"prices_ttl"`
+	FundamentalsTTL time.Duration `yaml:"fundamentals_ttl"`
+}
+
+// CSVColumns maps each price bar field to the header of its CSV column.
+type CSVColumns struct {
+	Date   string `yaml:"date"`
+	Open   string `yaml:"open"`
+	High   string `yaml:"high"`
+	Low    string `yaml:"low"`
+	Close  string `yaml:"close"`
+	Volume string `yaml:"volume"`
+}
+
+// CSVPrices holds the settings of the CSV prices provider. Zero values fall back to the Stooq format.
+type CSVPrices struct {
+	Source        string     `yaml:"source"` // URL or local path, with the {ticker} or {ticker_lower} placeholders.
+	DateFormat    string     `yaml:"date_format"`
+	Delimiter     string     `yaml:"delimiter"`
+	DecimalComma  bool       `yaml:"decimal_comma"`
+	Columns       CSVColumns `yaml:"columns"`
+	SplitAdjusted bool       `yaml:"split_adjusted"` // Whether the source is already split-adjusted, as Stooq is.
+}
+
+// FX holds the settings of the exchange rates used to convert the reports into another currency. The provider is
+// either ptax, the default, or csv, which reads the rates from the source.
+type FX struct {
+	Provider     string `yaml:"provider"`
+	Source       string `yaml:"source"` // URL or local path of the csv provider, with the {base} and {quote} placeholders.
+	DateFormat   string `yaml:"date_format"`
+	Delimiter    string `yaml:"delimiter"`
+	DecimalComma bool   `yaml:"decimal_comma"`
+	DateColumn   string `yaml:"date_column"`
+	RateColumn   string `yaml:"rate_column"`
+}
+
+// Holdings holds the settings of the holdings file read by the portfolio command. Zero values fall back to
+// comma-separated rows.
+type Holdings struct {
+	Path         string `yaml:"path"`
+	Delimiter    string `yaml:"delimiter"`
+	DecimalComma bool   `yaml:"decimal_comma"`
+}
+
+// Config represents the content of the configuration file.
+type Config struct {
+	TargetYieldPercentage  float64              `yaml:"target_yield"`
+	RiskFreeRatePercentage float64              `yaml:"risk_free_rate"`
+	Years                  int                  `yaml:"years"`
+	Provider               Providers            `yaml:"provider"`
+	Concurrency            int                  `yaml:"concurrency"`
+	Timeout                time.Duration        `yaml:"timeout"`
+	RequestTimeout         time.Duration        `yaml:"request_timeout"`
+	Cache                  Cache                `yaml:"cache"`
+	CSV                    CSVPrices            `yaml:"csv"`
+	ReportCurrency         string               `yaml:"report_currency"`
+	FX                     FX                   `yaml:"fx"`
+	Holdings               Holdings             `yaml:"holdings"`
+	Watchlists             map[string]Watchlist `yaml:"watchlists"`
+}
+
+// DefaultPath returns the configuration file path under the XDG configuration directory.
+func DefaultPath() (string, error) {
+	directory, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the user config directory: %w", err)
+	}
+
+	return filepath.Join(directory, appDirectory, fileName), nil
+}
+
+// Load reads and parses the configuration file at the given path.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %w", err)
+	}
+
+	config := &Config{}
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the config file %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// LoadDefault reads the configuration file from the default path, returning an empty configuration when it is missing.
+func LoadDefault() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+
+	return config, err
+}
+
+// Watchlist returns the named watchlist with its zero values filled from the top-level settings.
+func (c *Config) Watchlist(name string) (Watchlist, error) {
+	watchlist, exists := c.Watchlists[name]
+	if !exists {
+		return Watchlist{}, fmt.Errorf("%w: %s (available: %v)", ErrWatchlistNotFound, name, c.WatchlistNames())
+	}
+
+	watchlist.Tickers = slices.Clone(watchlist.Tickers)
+	if watchlist.TargetYieldPercentage == 0 {
+		watchlist.TargetYieldPercentage = c.TargetYieldPercentage
+	}
+	if watchlist.Years == 0 {
+		watchlist.Years = c.Years
+	}
+	if len(watchlist.Provider) == 0 {
+		watchlist.Provider = slices.Clone(c.Provider)
+	}
+
+	return watchlist, nil
+}
+
+// WatchlistNames returns the names of every watchlist in alphabetical order.
+func (c *Config) WatchlistNames() []string {
+	names := make([]string, 0, len(c.Watchlists))
+	for name := range c.Watchlists {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,145 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rios0rios0/investmate/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const content = `
+target_yield: 9
+years: 5
+provider: nasdaq
+watchlists:
+  covered-call:
+    tickers: [SVOL, XYLD]
+    target_yield: 12
+  core-index:
+    tickers: [SPY, QQQ]
+    years: 10
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestConfig_Load(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse the top-level settings and every watchlist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := writeConfig(t, content)
+
+		// when
+		result, err := config.Load(path)
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, 9.0, result.TargetYieldPercentage, 0.001)
+		assert.Equal(t, 5, result.Years)
+		assert.Equal(t, config.Providers{"nasdaq"}, result.Provider)
+		assert.Equal(t, []string{"core-index", "covered-call"}, result.WatchlistNames())
+	})
+
+	t.Run("should parse the providers fallback order from a list", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := writeConfig(t, "provider: [nasdaq, historyorg, statusinvest]")
+
+		// when
+		result, err := config.Load(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, config.Providers{"nasdaq", "historyorg", "statusinvest"}, result.Provider)
+	})
+
+	t.Run("should parse the report currency and the exchange rates settings", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := writeConfig(t, "report_currency: BRL\nfx:\n  provider: csv\n  source: /data/{base}_{quote}.csv\n")
+
+		// when
+		result, err := config.Load(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "BRL", result.ReportCurrency)
+		assert.Equal(t, config.FX{Provider: "csv", Source: "/data/{base}_{quote}.csv"}, result.FX)
+	})
+
+	t.Run("should return an error when the file is not valid YAML", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := writeConfig(t, "watchlists: [")
+
+		// when
+		_, err := config.Load(path)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should return an error when the file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "missing.yaml")
+
+		// when
+		_, err := config.Load(path)
+
+		// then
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestConfig_Watchlist(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should inherit the top-level settings the watchlist does not override", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cfg, err := config.Load(writeConfig(t, content))
+		require.NoError(t, err)
+
+		// when
+		result, err := cfg.Watchlist("covered-call")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SVOL", "XYLD"}, result.Tickers)
+		assert.InDelta(t, 12.0, result.TargetYieldPercentage, 0.001)
+		assert.Equal(t, 5, result.Years)
+		assert.Equal(t, config.Providers{"nasdaq"}, result.Provider)
+	})
+
+	t.Run("should return an error when the watchlist is not defined", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cfg, err := config.Load(writeConfig(t, content))
+		require.NoError(t, err)
+
+		// when
+		_, err = cfg.Watchlist("gold")
+
+		// then
+		assert.ErrorIs(t, err, config.ErrWatchlistNotFound)
+	})
+}
@@ -0,0 +1,56 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// CorrelationReport holds the correlation matrix of the watchlist and the threshold of the pairs worth a warning.
+type CorrelationReport struct {
+	Matrix    entities.CorrelationMatrix
+	Threshold float64 // Smallest correlation of two ETFs considered the same exposure.
+}
+
+// CorrelationRenderer writes a correlation matrix in a specific output format.
+type CorrelationRenderer interface {
+	RenderCorrelations(writer io.Writer, report CorrelationReport) error
+}
+
+// NewCorrelationRenderer creates the correlation matrix renderer for the given output format.
+func NewCorrelationRenderer(format string) (CorrelationRenderer, error) {
+	switch format {
+	case FormatTable:
+		return NewTableRenderer(), nil
+	case FormatJSON:
+		return NewJSONRenderer(), nil
+	case FormatCSV:
+		return NewCSVRenderer(), nil
+	case FormatMarkdown:
+		return NewMarkdownRenderer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// correlationHeaders builds the column headers shared by the table and Markdown correlation renderers.
+func correlationHeaders(report CorrelationReport) []string {
+	return append([]string{"ETF"}, report.Matrix.Names...)
+}
+
+// correlationRow builds the row of the ETF at the index for the table and Markdown renderers, using a dash for the
+// pairs without enough common dates.
+func correlationRow(report CorrelationReport, index int) []string {
+	row := []string{report.Matrix.Names[index]}
+
+	for _, correlation := range report.Matrix.Correlations[index] {
+		if correlation.Exists() {
+			row = append(row, fmt.Sprintf("%.2f", correlation.Coefficient))
+		} else {
+			row = append(row, "-")
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,312 @@
+package renderers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// metricDividends is the CSV metric name for the yearly dividend sums.
+	metricDividends = "dividends"
+
+	// metricSpecialDividends is the CSV metric name for the yearly sums of the payments that break the payout
+	// frequency, already included in the dividends.
+	metricSpecialDividends = "special_dividends"
+
+	// metricAverageClosingPrice is the CSV metric name for the yearly average closing prices.
+	metricAverageClosingPrice = "average_closing_price"
+
+	// metricDividendYield is the CSV metric name for the yearly dividend yield percentages.
+	metricDividendYield = "dividend_yield_percentage"
+
+	// periodTTM is the CSV year value of the trailing-twelve-month figures.
+	periodTTM = "TTM"
+
+	// periodForward is the CSV year value of the forward figures.
+	periodForward = "Forward"
+
+	// metricDividendCAGR is the CSV metric name for the dividend growth rate percentages, whose year value is the
+	// period, such as "5Y".
+	metricDividendCAGR = "dividend_cagr_percentage"
+
+	// metricGrowthStreak is the CSV metric name for the consecutive years of dividend increase up to the year.
+	metricGrowthStreak = "dividend_growth_streak_years"
+
+	// metricDividendCut is the CSV metric name for the percentage change of the years with a dividend cut.
+	metricDividendCut = "dividend_cut_percentage"
+
+	// metricPriceReturn is the CSV metric name for the yearly price return percentages.
+	metricPriceReturn = "price_return_percentage"
+
+	// metricTotalReturn is the CSV metric name for the yearly total return percentages, with the distributions
+	// kept in cash.
+	metricTotalReturn = "total_return_percentage"
+
+	// metricReinvestedReturn is the CSV metric name for the yearly total return percentages, with the
+	// distributions reinvested.
+	metricReinvestedReturn = "reinvested_total_return_percentage"
+
+	// metricNAVErosion is the CSV metric name for the NAV erosion percentage, whose year value is the span of the
+	// report, such as "2021-2025".
+	metricNAVErosion = "nav_erosion_percentage"
+
+	// metricVolatility is the CSV metric name for the annualized volatility percentages of each year and of the
+	// report span.
+	metricVolatility = "volatility_percentage"
+
+	// metricMaxDrawdown is the CSV metric name for the maximum drawdown percentages of each year and of the report
+	// span.
+	metricMaxDrawdown = "max_drawdown_percentage"
+
+	// metricSharpe is the CSV metric name for the Sharpe ratios of each year and of the report span.
+	metricSharpe = "sharpe_ratio"
+
+	// metricSortino is the CSV metric name for the Sortino ratios of each year and of the report span.
+	metricSortino = "sortino_ratio"
+)
+
+// CSVRenderer renders the report with one row per ETF, year and metric, skipping the years without data.
+type CSVRenderer struct {
+}
+
+func NewCSVRenderer() *CSVRenderer {
+	return &CSVRenderer{}
+}
+
+func (r *CSVRenderer) Render(writer io.Writer, report Report) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write([]string{"etf", "year", "metric", "value"}); err != nil {
+		return fmt.Errorf("failed to write the CSV header: %w", err)
+	}
+
+	for _, etf := range report.ETFs {
+		yields := etf.CalculateDividendYieldPerYear(report.CurrentYear, report.TotalYears)
+		specialDividends := entities.SumDividendsPerYear(etf.SpecialDividends())
+
+		for _, year := range report.Years() {
+			metrics := []struct {
+				name   string
+				values map[string]float64
+			}{
+				{metricDividends, etf.AmountDividendsPerYear},
+				{metricSpecialDividends, specialDividends},
+				{metricAverageClosingPrice, etf.AverageClosingPricePerYear},
+				{metricDividendYield, yields},
+			}
+
+			for _, metric := range metrics {
+				value, exists := metric.values[year]
+				if !exists {
+					continue
+				}
+
+				record := []string{etf.Name, year, metric.name, strconv.FormatFloat(value, 'f', -1, 64)}
+				if err := csvWriter.Write(record); err != nil {
+					return fmt.Errorf("failed to write the CSV row for ETF %s: %w", etf.Name, err)
+				}
+			}
+		}
+
+		if err := writeTrailingRecords(csvWriter, etf, report); err != nil {
+			return err
+		}
+
+		if err := writeDividendGrowthRecords(csvWriter, etf, report); err != nil {
+			return err
+		}
+
+		if err := writeReturnRecords(csvWriter, etf, report); err != nil {
+			return err
+		}
+
+		if err := writeRiskRecords(csvWriter, etf, report); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to flush the CSV output: %w", err)
+	}
+
+	return nil
+}
+
+// writeTrailingRecords writes the trailing-twelve-month and forward figures that are available for the ETF.
+func writeTrailingRecords(csvWriter *csv.Writer, etf *entities.ETF, report Report) error {
+	ttmDividends, ttmDividendsExist := etf.TTMDividends(report.Now), len(etf.Dividends) > 0
+	ttmYield, ttmYieldExists := etf.TTMYield(report.Now)
+	forwardDividends, forwardDividendsExist := etf.ForwardDividends()
+	forwardYield, forwardYieldExists := etf.ForwardYield()
+
+	figures := []struct {
+		period string
+		metric string
+		value  float64
+		exists bool
+	}{
+		{periodTTM, metricDividends, ttmDividends, ttmDividendsExist},
+		{periodTTM, metricDividendYield, ttmYield, ttmYieldExists},
+		{periodForward, metricDividends, forwardDividends, forwardDividendsExist},
+		{periodForward, metricDividendYield, forwardYield, forwardYieldExists},
+	}
+
+	for _, figure := range figures {
+		if !figure.exists {
+			continue
+		}
+
+		record := []string{etf.Name, figure.period, figure.metric, strconv.FormatFloat(figure.value, 'f', -1, 64)}
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write the CSV row for ETF %s: %w", etf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeDividendGrowthRecords writes the available dividend growth rates, the growth streak and every dividend cut.
+func writeDividendGrowthRecords(csvWriter *csv.Writer, etf *entities.ETF, report Report) error {
+	var records [][]string
+
+	for _, years := range entities.CAGRPeriods() {
+		if cagr, exists := etf.DividendCAGR(report.CurrentYear, years); exists {
+			period := strconv.Itoa(years) + "Y"
+			records = append(records, []string{etf.Name, period, metricDividendCAGR, strconv.FormatFloat(cagr, 'f', -1, 64)})
+		}
+	}
+
+	if len(etf.Dividends) > 0 {
+		lastYear := strconv.Itoa(report.CurrentYear - 1)
+		streak := strconv.Itoa(etf.DividendGrowthStreak(report.CurrentYear))
+		records = append(records, []string{etf.Name, lastYear, metricGrowthStreak, streak})
+	}
+
+	for _, cut := range etf.DividendCuts(report.CurrentYear, report.CutThresholdPercentage) {
+		change := strconv.FormatFloat(cut.ChangePercentage, 'f', -1, 64)
+		records = append(records, []string{etf.Name, strconv.Itoa(cut.Year), metricDividendCut, change})
+	}
+
+	for _, record := range records {
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write the CSV row for ETF %s: %w", etf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeReturnRecords writes the price and total returns of every year with prices, and the NAV erosion of the
+// report span.
+func writeReturnRecords(csvWriter *csv.Writer, etf *entities.ETF, report Report) error {
+	var records [][]string
+
+	for _, year := range report.Years() {
+		number, _ := strconv.Atoi(year)
+
+		returns, exists := etf.ReturnsOf(number)
+		if !exists {
+			continue
+		}
+
+		records = append(records,
+			[]string{etf.Name, year, metricPriceReturn, strconv.FormatFloat(returns.Price, 'f', -1, 64)},
+			[]string{etf.Name, year, metricTotalReturn, strconv.FormatFloat(returns.Total, 'f', -1, 64)},
+			[]string{etf.Name, year, metricReinvestedReturn, strconv.FormatFloat(returns.Reinvested, 'f', -1, 64)},
+		)
+	}
+
+	if erosion, exists := etf.NAVErosion(report.CurrentYear, report.TotalYears); exists {
+		records = append(records,
+			[]string{etf.Name, report.Span(), metricNAVErosion, strconv.FormatFloat(erosion, 'f', -1, 64)},
+		)
+	}
+
+	for _, record := range records {
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write the CSV row for ETF %s: %w", etf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeRiskRecords writes the volatility, the maximum drawdown and the Sharpe and Sortino ratios of every year with
+// enough prices, followed by the ones of the report span.
+func writeRiskRecords(csvWriter *csv.Writer, etf *entities.ETF, report Report) error {
+	var records [][]string
+
+	appendRisk := func(period string, risk entities.Risk) {
+		records = append(records,
+			[]string{etf.Name, period, metricVolatility, strconv.FormatFloat(risk.Volatility, 'f', -1, 64)},
+			[]string{etf.Name, period, metricMaxDrawdown, strconv.FormatFloat(risk.MaxDrawdown.Percentage, 'f', -1, 64)},
+		)
+
+		if sharpe, exists := risk.Sharpe(); exists {
+			records = append(records, []string{etf.Name, period, metricSharpe, strconv.FormatFloat(sharpe, 'f', -1, 64)})
+		}
+
+		if sortino, exists := risk.Sortino(); exists {
+			records = append(records, []string{etf.Name, period, metricSortino, strconv.FormatFloat(sortino, 'f', -1, 64)})
+		}
+	}
+
+	for _, year := range report.Years() {
+		number, _ := strconv.Atoi(year)
+		if risk, exists := etf.RiskOf(number, report.RiskFreeRatePercentage); exists {
+			appendRisk(year, risk)
+		}
+	}
+
+	if risk, exists := etf.RiskOver(report.CurrentYear, report.TotalYears, report.RiskFreeRatePercentage); exists {
+		appendRisk(report.Span(), risk)
+	}
+
+	for _, record := range records {
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write the CSV row for ETF %s: %w", etf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// RenderCorrelations renders the correlation matrix with one row and one column per ETF, leaving empty the pairs
+// without enough common dates.
+func (r *CSVRenderer) RenderCorrelations(writer io.Writer, report CorrelationReport) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write(append([]string{"etf"}, report.Matrix.Names...)); err != nil {
+		return fmt.Errorf("failed to write the CSV header: %w", err)
+	}
+
+	for i, name := range report.Matrix.Names {
+		record := []string{name}
+
+		for _, correlation := range report.Matrix.Correlations[i] {
+			if correlation.Exists() {
+				record = append(record, strconv.FormatFloat(correlation.Coefficient, 'f', -1, 64))
+			} else {
+				record = append(record, "")
+			}
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write the CSV row for ETF %s: %w", name, err)
+		}
+	}
+
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to flush the CSV output: %w", err)
+	}
+
+	return nil
+}
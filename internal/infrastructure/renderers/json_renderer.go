@@ -0,0 +1,304 @@
+package renderers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+type jsonFundamentals struct {
+	PayoutFrequency string  `json:"payout_frequency,omitempty"`
+	AverageVolume   float64 `json:"average_volume,omitempty"`
+	ExpenseRatio    float64 `json:"expense_ratio_percentage,omitempty"`
+	Beta            float64 `json:"beta,omitempty"`
+	AUM             float64 `json:"aum,omitempty"`
+	InceptionDate   string  `json:"inception_date,omitempty"`
+}
+
+type jsonAverages struct {
+	Dividends     float64 `json:"dividends"`
+	ClosingPrice  float64 `json:"closing_price"`
+	DividendYield float64 `json:"dividend_yield_percentage"`
+}
+
+type jsonYear struct {
+	Year                int       `json:"year"`
+	Dividends           *float64  `json:"dividends"`
+	SpecialDividends    *float64  `json:"special_dividends"`
+	AverageClosingPrice *float64  `json:"average_closing_price"`
+	DividendYield       *float64  `json:"dividend_yield_percentage"`
+	PriceReturn         *float64  `json:"price_return_percentage"`
+	TotalReturn         *float64  `json:"total_return_percentage"`
+	ReinvestedReturn    *float64  `json:"reinvested_total_return_percentage"`
+	Risk                *jsonRisk `json:"risk"`
+	DividendsSource     string    `json:"dividends_source,omitempty"`
+	PricesSource        string    `json:"prices_source,omitempty"`
+}
+
+type jsonTrailing struct {
+	Dividends     *float64 `json:"dividends"`
+	DividendYield *float64 `json:"dividend_yield_percentage"`
+}
+
+type jsonDividendCut struct {
+	Year             int     `json:"year"`
+	PreviousAmount   float64 `json:"previous_dividends"`
+	Amount           float64 `json:"dividends"`
+	ChangePercentage float64 `json:"change_percentage"`
+}
+
+type jsonDividendGrowth struct {
+	CAGR         map[string]*float64 `json:"cagr_percentage"` // Key: Period, such as "5y".
+	GrowthStreak int                 `json:"growth_streak_years"`
+	Cuts         []jsonDividendCut   `json:"cuts"`
+}
+
+type jsonDrawdown struct {
+	Percentage float64 `json:"percentage"`
+	Peak       string  `json:"peak_date,omitempty"`
+	Trough     string  `json:"trough_date,omitempty"`
+}
+
+type jsonRisk struct {
+	Volatility  float64      `json:"volatility_percentage"`
+	MaxDrawdown jsonDrawdown `json:"max_drawdown"`
+	Sharpe      *float64     `json:"sharpe_ratio"`
+	Sortino     *float64     `json:"sortino_ratio"`
+}
+
+type jsonETF struct {
+	Name           string             `json:"name"`
+	Market         string             `json:"market,omitempty"`
+	Currency       string             `json:"currency,omitempty"`
+	Fundamentals   jsonFundamentals   `json:"fundamentals"`
+	Averages       jsonAverages       `json:"averages"`
+	LatestClose    *float64           `json:"latest_close"`
+	TTM            jsonTrailing       `json:"ttm"`
+	Forward        jsonTrailing       `json:"forward"`
+	DividendGrowth jsonDividendGrowth `json:"dividend_growth"`
+	NAVErosion     *float64           `json:"nav_erosion_percentage"`
+	YieldTrap      bool               `json:"yield_trap"`
+	Risk           *jsonRisk          `json:"risk"`
+	Years          []jsonYear         `json:"years"`
+}
+
+type jsonReport struct {
+	TargetYieldPercentage  float64   `json:"target_yield_percentage"`
+	RiskFreeRatePercentage float64   `json:"risk_free_rate_percentage"`
+	ETFs                   []jsonETF `json:"etfs"`
+}
+
+type jsonCorrelatedPair struct {
+	First        string  `json:"first"`
+	Second       string  `json:"second"`
+	Coefficient  float64 `json:"coefficient"`
+	Observations int     `json:"observations"`
+}
+
+type jsonCorrelationReport struct {
+	Threshold      float64              `json:"threshold"`
+	Names          []string             `json:"etfs"`
+	Matrix         [][]*float64         `json:"matrix"`
+	AboveThreshold []jsonCorrelatedPair `json:"pairs_above_threshold"`
+}
+
+// JSONRenderer renders the report as structured per-ETF per-year objects.
+type JSONRenderer struct {
+}
+
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+func (r *JSONRenderer) Render(writer io.Writer, report Report) error {
+	output := jsonReport{
+		TargetYieldPercentage:  report.TargetYieldPercentage,
+		RiskFreeRatePercentage: report.RiskFreeRatePercentage,
+		ETFs:                   make([]jsonETF, 0, len(report.ETFs)),
+	}
+
+	for _, etf := range report.ETFs {
+		output.ETFs = append(output.ETFs, newJSONETF(etf, report))
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("failed to encode the report: %w", err)
+	}
+
+	return nil
+}
+
+// newJSONETF converts an ETF into its JSON representation, using null for the years without data.
+func newJSONETF(etf *entities.ETF, report Report) jsonETF {
+	yields := etf.CalculateDividendYieldPerYear(report.CurrentYear, report.TotalYears)
+	specialDividends := entities.SumDividendsPerYear(etf.SpecialDividends())
+
+	output := jsonETF{
+		Name:     etf.Name,
+		Market:   string(etf.Market),
+		Currency: etf.Currency,
+		Fundamentals: jsonFundamentals{
+			PayoutFrequency: etf.PayoutFrequencyName(),
+			AverageVolume:   etf.AverageVolume,
+			ExpenseRatio:    etf.ExpenseRatio,
+			Beta:            etf.Beta,
+			AUM:             etf.AUM,
+		},
+		Averages: jsonAverages{
+			Dividends:     etf.AverageDividends(report.CurrentYear, report.TotalYears),
+			ClosingPrice:  etf.AverageClosingPrices(report.CurrentYear, report.TotalYears),
+			DividendYield: etf.AverageDividendYield(report.CurrentYear, report.TotalYears),
+		},
+		Years: make([]jsonYear, 0, report.TotalYears),
+	}
+
+	if bar, exists := etf.LastPriceBar(); exists {
+		output.LatestClose = &bar.Close
+	}
+
+	if len(etf.Dividends) > 0 {
+		ttmDividends := etf.TTMDividends(report.Now)
+		output.TTM.Dividends = &ttmDividends
+	}
+
+	output.TTM.DividendYield = optional(etf.TTMYield(report.Now))
+	output.Forward.Dividends = optional(etf.ForwardDividends())
+	output.Forward.DividendYield = optional(etf.ForwardYield())
+
+	output.DividendGrowth = newJSONDividendGrowth(etf, report)
+	output.NAVErosion = optional(etf.NAVErosion(report.CurrentYear, report.TotalYears))
+	output.YieldTrap = etf.IsYieldTrap(report.CurrentYear, report.TotalYears, report.TargetYieldPercentage)
+	output.Risk = newJSONRisk(etf.RiskOver(report.CurrentYear, report.TotalYears, report.RiskFreeRatePercentage))
+
+	if !etf.InceptionDate.IsZero() {
+		output.Fundamentals.InceptionDate = etf.InceptionDate.Format(time.DateOnly)
+	}
+
+	for _, year := range report.Years() {
+		number, _ := strconv.Atoi(year)
+		entry := jsonYear{
+			Year:                number,
+			Dividends:           valueOf(etf.AmountDividendsPerYear, year),
+			SpecialDividends:    valueOf(specialDividends, year),
+			AverageClosingPrice: valueOf(etf.AverageClosingPricePerYear, year),
+			DividendYield:       valueOf(yields, year),
+			DividendsSource:     etf.DividendSourcePerYear[year],
+			PricesSource:        etf.PriceSourcePerYear[year],
+		}
+
+		if returns, exists := etf.ReturnsOf(number); exists {
+			entry.PriceReturn, entry.TotalReturn, entry.ReinvestedReturn = &returns.Price, &returns.Total,
+				&returns.Reinvested
+		}
+
+		entry.Risk = newJSONRisk(etf.RiskOf(number, report.RiskFreeRatePercentage))
+
+		output.Years = append(output.Years, entry)
+	}
+
+	return output
+}
+
+// newJSONDividendGrowth converts the dividend growth rates, streak and cuts of an ETF, using null for the growth
+// rates of the periods longer than its history.
+func newJSONDividendGrowth(etf *entities.ETF, report Report) jsonDividendGrowth {
+	growth := jsonDividendGrowth{
+		CAGR:         make(map[string]*float64, len(entities.CAGRPeriods())),
+		GrowthStreak: etf.DividendGrowthStreak(report.CurrentYear),
+		Cuts:         []jsonDividendCut{},
+	}
+
+	for _, years := range entities.CAGRPeriods() {
+		growth.CAGR[fmt.Sprintf("%dy", years)] = optional(etf.DividendCAGR(report.CurrentYear, years))
+	}
+
+	for _, cut := range etf.DividendCuts(report.CurrentYear, report.CutThresholdPercentage) {
+		growth.Cuts = append(growth.Cuts, jsonDividendCut(cut))
+	}
+
+	return growth
+}
+
+// newJSONRisk converts the risk metrics of a period, returning nil when they are not available.
+func newJSONRisk(risk entities.Risk, exists bool) *jsonRisk {
+	if !exists {
+		return nil
+	}
+
+	output := &jsonRisk{
+		Volatility:  risk.Volatility,
+		MaxDrawdown: jsonDrawdown{Percentage: risk.MaxDrawdown.Percentage},
+		Sharpe:      optional(risk.Sharpe()),
+		Sortino:     optional(risk.Sortino()),
+	}
+
+	if !risk.MaxDrawdown.Trough.IsZero() {
+		output.MaxDrawdown.Peak = risk.MaxDrawdown.Peak.Format(time.DateOnly)
+		output.MaxDrawdown.Trough = risk.MaxDrawdown.Trough.Format(time.DateOnly)
+	}
+
+	return output
+}
+
+// valueOf returns a pointer to the value stored under the key, or nil when it is missing.
+func valueOf(values map[string]float64, key string) *float64 {
+	if value, exists := values[key]; exists {
+		return &value
+	}
+
+	return nil
+}
+
+// optional returns a pointer to the calculated value, or nil when it is not available.
+func optional(value float64, exists bool) *float64 {
+	if !exists {
+		return nil
+	}
+
+	return &value
+}
+
+// RenderCorrelations renders the correlation matrix, using null for the pairs without enough common dates, and the
+// pairs correlated at or above the threshold.
+func (r *JSONRenderer) RenderCorrelations(writer io.Writer, report CorrelationReport) error {
+	output := jsonCorrelationReport{
+		Threshold:      report.Threshold,
+		Names:          report.Matrix.Names,
+		Matrix:         make([][]*float64, 0, len(report.Matrix.Names)),
+		AboveThreshold: []jsonCorrelatedPair{},
+	}
+
+	for _, correlations := range report.Matrix.Correlations {
+		row := make([]*float64, 0, len(correlations))
+		for _, correlation := range correlations {
+			row = append(row, optional(correlation.Coefficient, correlation.Exists()))
+		}
+
+		output.Matrix = append(output.Matrix, row)
+	}
+
+	for _, pair := range report.Matrix.PairsAbove(report.Threshold) {
+		output.AboveThreshold = append(output.AboveThreshold, jsonCorrelatedPair{
+			First:        pair.First,
+			Second:       pair.Second,
+			Coefficient:  pair.Correlation.Coefficient,
+			Observations: pair.Correlation.Observations,
+		})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("failed to encode the correlation matrix: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// MarkdownRenderer renders the report as a Markdown table without any ANSI color codes.
+type MarkdownRenderer struct {
+}
+
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+func (r *MarkdownRenderer) Render(writer io.Writer, report Report) error {
+	table := tablewriter.NewTable(
+		writer,
+		tablewriter.WithRenderer(renderer.NewMarkdown()),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+	)
+	table.Header(headers(report))
+
+	for _, etf := range report.ETFs {
+		dividendRow, closePriceRow, dividendYieldRow := etfRows(etf, report)
+
+		rows := [][]string{dividendRow, closePriceRow, dividendYieldRow, totalReturnRow(etf, report)}
+		if report.ShowSources {
+			rows = append(rows, sourceRow(etf, report))
+		}
+
+		if err := table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to append rows for ETF %s: %w", etf.Name, err)
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
+
+// RenderCorrelations renders the correlation matrix as a Markdown table without any ANSI color codes.
+func (r *MarkdownRenderer) RenderCorrelations(writer io.Writer, report CorrelationReport) error {
+	table := tablewriter.NewTable(
+		writer,
+		tablewriter.WithRenderer(renderer.NewMarkdown()),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+	)
+	table.Header(correlationHeaders(report))
+
+	rows := make([][]string, 0, len(report.Matrix.Names))
+	for i := range report.Matrix.Names {
+		rows = append(rows, correlationRow(report, i))
+	}
+
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to append the correlation rows: %w", err)
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package renderers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// FormatTable renders an ANSI colored table for the terminal.
+	FormatTable = "table"
+
+	// FormatJSON renders structured per-ETF per-year objects.
+	FormatJSON = "json"
+
+	// FormatCSV renders one row per ETF, year and metric.
+	FormatCSV = "csv"
+
+	// FormatMarkdown renders a Markdown table.
+	FormatMarkdown = "markdown"
+)
+
+// ErrUnknownFormat is returned when the requested output format is not supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Report holds the ETFs and the settings every renderer needs to build its output.
+type Report struct {
+	ETFs                   []*entities.ETF
+	Now                    time.Time // Reference moment of the trailing-twelve-month figures.
+	CurrentYear            int
+	TotalYears             int
+	TargetYieldPercentage  float64
+	ShowSources            bool    // Whether the table renderers add a row with the provider of each year.
+	CutThresholdPercentage float64 // Smallest drop of the yearly dividends, in percent, shown as the last cut.
+	RiskFreeRatePercentage float64 // Yearly rate the Sharpe and Sortino ratios measure the excess return over.
+}
+
+// Years returns the years covered by the report, from the most recent backwards.
+func (r Report) Years() []string {
+	years := make([]string, r.TotalYears)
+	for i := range r.TotalYears {
+		years[i] = strconv.Itoa(r.CurrentYear - i)
+	}
+
+	return years
+}
+
+// Span returns the first and the last year covered by the report, such as "2021-2025".
+func (r Report) Span() string {
+	return fmt.Sprintf("%d-%d", r.CurrentYear-r.TotalYears+1, r.CurrentYear)
+}
+
+// Renderer writes a report in a specific output format.
+type Renderer interface {
+	Render(writer io.Writer, report Report) error
+}
+
+// NewRenderer creates the renderer for the given output format.
+func NewRenderer(format string) (Renderer, error) {
+	switch format {
+	case FormatTable:
+		return NewTableRenderer(), nil
+	case FormatJSON:
+		return NewJSONRenderer(), nil
+	case FormatCSV:
+		return NewCSVRenderer(), nil
+	case FormatMarkdown:
+		return NewMarkdownRenderer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// Formats lists every supported output format.
+func Formats() []string {
+	return []string{FormatTable, FormatJSON, FormatCSV, FormatMarkdown}
+}
+
+// headers builds the column headers shared by the table and Markdown renderers.
+func headers(report Report) []string {
+	headers := []string{"ETF"}
+	headers = append(headers, report.Years()...)
+	headers = append(headers, "Averages", "TTM", "Forward")
+
+	for _, years := range entities.CAGRPeriods() {
+		headers = append(headers, fmt.Sprintf("CAGR %dY", years))
+	}
+
+	headers = append(headers,
+		"Growth Streak", "Last Cut", "NAV Erosion", "Volatility", "Max Drawdown", "Sharpe", "Sortino",
+		"Payout Frequency", "Average Volume", "Expense Ratio", "Beta", "AUM", "Inception Date",
+	)
+
+	return headers
+}
+
+// etfRows builds the dividends, closing prices and dividend yields rows of an ETF for the table and
+// Markdown renderers. The dividend growth, the risk and the fundamentals are shown once per ETF, the remaining rows
+// leave those cells blank.
+func etfRows(etf *entities.ETF, report Report) (dividendRow, closePriceRow, dividendYieldRow []string) {
+	currentYear, totalYears := report.CurrentYear, report.TotalYears
+	growthAndRisk := append(
+		etf.ShowDividendGrowth(currentYear, report.CutThresholdPercentage),
+		etf.ShowNAVErosion(currentYear, totalYears, report.TargetYieldPercentage),
+	)
+	growthAndRisk = append(growthAndRisk, etf.ShowRisk(currentYear, totalYears, report.RiskFreeRatePercentage)...)
+	blankCells := make([]string, len(growthAndRisk)+len(entities.Fundamentals{}.ShowFundamentals()))
+
+	dividendRow = []string{etf.Name + " Dividends"}
+	dividendRow = append(dividendRow, etf.ShowDividendsPerYear(currentYear, totalYears)...)
+	dividendRow = append(dividendRow, etf.FormatAmount(etf.AverageDividends(currentYear, totalYears)))
+	dividendRow = append(dividendRow, etf.FormatAmount(etf.TTMDividends(report.Now)))
+	dividendRow = append(dividendRow, ShowValue(entities.CurrencySymbol(etf.Currency)+"%.3f", etf.ForwardDividends))
+	dividendRow = append(dividendRow, growthAndRisk...)
+	dividendRow = append(dividendRow, etf.ShowFundamentals()...)
+
+	closePriceRow = []string{etf.Name + " Closing Prices"}
+	closePriceRow = append(closePriceRow, etf.ShowClosingPricesPerYear(currentYear, totalYears)...)
+	closePriceRow = append(closePriceRow, etf.FormatAmount(etf.AverageClosingPrices(currentYear, totalYears)))
+
+	// Both forward-looking yields are calculated over the latest closing price.
+	latestClose := "-"
+	if bar, exists := etf.LastPriceBar(); exists {
+		latestClose = etf.FormatAmount(bar.Close)
+	}
+
+	closePriceRow = append(closePriceRow, latestClose, latestClose)
+	closePriceRow = append(closePriceRow, blankCells...)
+
+	dividendYieldRow = []string{etf.Name + " Dividend Yields"}
+	dividendYieldRow = append(dividendYieldRow, etf.ShowDividendYieldPerYear(currentYear, totalYears)...)
+	dividendYieldRow = append(
+		dividendYieldRow,
+		fmt.Sprintf("%.3f%%", etf.AverageDividendYield(currentYear, totalYears)),
+		ShowValue("%.3f%%", func() (float64, bool) { return etf.TTMYield(report.Now) }),
+		ShowValue("%.3f%%", etf.ForwardYield),
+	)
+	dividendYieldRow = append(dividendYieldRow, blankCells...)
+
+	return dividendRow, closePriceRow, dividendYieldRow
+}
+
+// totalReturnRow builds the row of the yearly total returns, with the distributions reinvested, for the table and
+// Markdown renderers.
+func totalReturnRow(etf *entities.ETF, report Report) []string {
+	row := []string{etf.Name + " Total Returns"}
+	row = append(row, etf.ShowTotalReturnsPerYear(report.CurrentYear, report.TotalYears)...)
+	row = append(row, ShowValue("%+.2f%%", func() (float64, bool) {
+		return etf.AverageTotalReturn(report.CurrentYear, report.TotalYears)
+	}))
+
+	return append(row, make([]string, len(headers(report))-len(row))...)
+}
+
+// ShowValue formats the value returned by the calculation, using a dash when it is not available.
+func ShowValue(format string, calculate func() (float64, bool)) string {
+	value, exists := calculate()
+	if !exists {
+		return "-"
+	}
+
+	return fmt.Sprintf(format, value)
+}
+
+// sourceRow builds the row naming the provider that supplied the data of each year, for the table and Markdown
+// renderers when more than one provider is chained.
+func sourceRow(etf *entities.ETF, report Report) []string {
+	row := []string{etf.Name + " Sources"}
+	row = append(row, etf.ShowSourcesPerYear(report.CurrentYear, report.TotalYears)...)
+
+	return append(row, make([]string, len(headers(report))-len(row))...)
+}
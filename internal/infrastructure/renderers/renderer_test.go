@@ -0,0 +1,499 @@
+package renderers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/renderers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReport() renderers.Report {
+	return renderers.Report{
+		ETFs: []*entities.ETF{{
+			Name:                       "SPY",
+			AmountDividendsPerYear:     map[string]float64{"2025": 6.0},
+			AverageClosingPricePerYear: map[string]float64{"2025": 600.0, "2024": 500.0},
+		}},
+		CurrentYear:           2025,
+		TotalYears:            2,
+		TargetYieldPercentage: 9,
+	}
+}
+
+func TestRenderer_NewRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should create a renderer for every supported format", func(t *testing.T) {
+		t.Parallel()
+
+		for _, format := range renderers.Formats() {
+			// when
+			renderer, err := renderers.NewRenderer(format)
+
+			// then
+			require.NoError(t, err)
+			assert.NotNil(t, renderer)
+		}
+	})
+
+	t.Run("should return an error when the format is not supported", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		_, err := renderers.NewRenderer("xml")
+
+		// then
+		assert.ErrorIs(t, err, renderers.ErrUnknownFormat)
+	})
+}
+
+func TestRenderer_JSONRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render per-year objects with null for the missing values", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewJSONRenderer().Render(&buffer, newReport())
+
+		// then
+		require.NoError(t, err)
+
+		var output struct {
+			ETFs []struct {
+				Name  string `json:"name"`
+				Years []struct {
+					Year          int      `json:"year"`
+					Dividends     *float64 `json:"dividends"`
+					DividendYield *float64 `json:"dividend_yield_percentage"`
+				} `json:"years"`
+			} `json:"etfs"`
+		}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+		require.Len(t, output.ETFs, 1)
+		require.Len(t, output.ETFs[0].Years, 2)
+		assert.Equal(t, 2025, output.ETFs[0].Years[0].Year)
+		assert.InDelta(t, 1.0, *output.ETFs[0].Years[0].DividendYield, 0.001)
+		assert.Nil(t, output.ETFs[0].Years[1].Dividends)
+	})
+}
+
+func TestRenderer_CSVRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render one row per ETF, year and available metric", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().Render(&buffer, newReport())
+
+		// then
+		require.NoError(t, err)
+
+		expected := "etf,year,metric,value\n" +
+			"SPY,2025,dividends,6\n" +
+			"SPY,2025,average_closing_price,600\n" +
+			"SPY,2025,dividend_yield_percentage,1\n" +
+			"SPY,2024,average_closing_price,500\n"
+		assert.Equal(t, expected, buffer.String())
+	})
+}
+
+func TestRenderer_DividendGrowth(t *testing.T) {
+	t.Parallel()
+
+	newGrowthReport := func() renderers.Report {
+		etf := entities.NewETF("SCHD")
+		etf.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2021, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+			{PaymentDate: time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 2.0},
+			{PaymentDate: time.Date(2023, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 1.5},
+			{PaymentDate: time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC), Amount: 3.0},
+		})
+
+		return renderers.Report{
+			ETFs:                   []*entities.ETF{etf},
+			CurrentYear:            2025,
+			TotalYears:             1,
+			CutThresholdPercentage: entities.DefaultCutThresholdPercentage,
+		}
+	}
+
+	t.Run("should render the growth rates, the streak and the cuts as CSV records", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().Render(&buffer, newGrowthReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "SCHD,1Y,dividend_cagr_percentage,100\n")
+		assert.Contains(t, buffer.String(), "SCHD,2024,dividend_growth_streak_years,1\n")
+		assert.Contains(t, buffer.String(), "SCHD,2023,dividend_cut_percentage,-25\n")
+	})
+
+	t.Run("should render the growth rates of the periods longer than the history as null", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewJSONRenderer().Render(&buffer, newGrowthReport())
+
+		// then
+		require.NoError(t, err)
+
+		var output struct {
+			ETFs []struct {
+				DividendGrowth struct {
+					CAGR map[string]*float64 `json:"cagr_percentage"`
+					Cuts []struct {
+						Year int `json:"year"`
+					} `json:"cuts"`
+				} `json:"dividend_growth"`
+			} `json:"etfs"`
+		}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+		require.Len(t, output.ETFs, 1)
+		assert.InDelta(t, 100.0, *output.ETFs[0].DividendGrowth.CAGR["1y"], 0.001)
+		assert.Nil(t, output.ETFs[0].DividendGrowth.CAGR["3y"])
+		assert.Len(t, output.ETFs[0].DividendGrowth.Cuts, 1)
+	})
+}
+
+func TestRenderer_TotalReturns(t *testing.T) {
+	t.Parallel()
+
+	newReturnsReport := func() renderers.Report {
+		etf := entities.NewETF("SVOL")
+		etf.SetPriceBars([]entities.PriceBar{
+			{Date: time.Date(2023, time.December, 29, 0, 0, 0, 0, time.UTC), Close: 100},
+			{Date: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), Close: 95},
+			{Date: time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC), Close: 90},
+			{Date: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Close: 85},
+		})
+		etf.SetDividends([]entities.Dividend{
+			{
+				ExDate:      time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+				PaymentDate: time.Date(2024, time.March, 22, 0, 0, 0, 0, time.UTC),
+				Amount:      3,
+			},
+			{
+				ExDate:      time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC),
+				PaymentDate: time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC),
+				Amount:      3,
+			},
+		})
+
+		return renderers.Report{
+			ETFs:                  []*entities.ETF{etf},
+			TargetYieldPercentage: 5,
+			CurrentYear:           2024,
+			TotalYears:            1,
+		}
+	}
+
+	t.Run("should render the total returns row and flag the yield trap in the table", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewMarkdownRenderer().Render(&buffer, newReturnsReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "SVOL Total Returns")
+		assert.Contains(t, buffer.String(), "-9.39%")
+		assert.Contains(t, buffer.String(), "250.00% (yield trap)")
+	})
+
+	t.Run("should render the returns per year and the NAV erosion as CSV records", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().Render(&buffer, newReturnsReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "SVOL,2024,price_return_percentage,-15.0")
+		assert.Contains(t, buffer.String(), "SVOL,2024,total_return_percentage,-8.99")
+		assert.Contains(t, buffer.String(), "SVOL,2024,reinvested_total_return_percentage,-9.39")
+		assert.Contains(t, buffer.String(), "SVOL,2024-2024,nav_erosion_percentage,249.99")
+	})
+
+	t.Run("should render the NAV erosion and the yield trap flag as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewJSONRenderer().Render(&buffer, newReturnsReport())
+
+		// then
+		require.NoError(t, err)
+
+		var output struct {
+			ETFs []struct {
+				NAVErosion *float64 `json:"nav_erosion_percentage"`
+				YieldTrap  bool     `json:"yield_trap"`
+				Years      []struct {
+					TotalReturn *float64 `json:"total_return_percentage"`
+				} `json:"years"`
+			} `json:"etfs"`
+		}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+		require.Len(t, output.ETFs, 1)
+		assert.InDelta(t, 250.0, *output.ETFs[0].NAVErosion, 0.001)
+		assert.True(t, output.ETFs[0].YieldTrap)
+		require.Len(t, output.ETFs[0].Years, 1)
+		assert.InDelta(t, -9.0, *output.ETFs[0].Years[0].TotalReturn, 0.001)
+	})
+}
+
+func TestRenderer_PayoutFrequency(t *testing.T) {
+	t.Parallel()
+
+	newFrequencyReport := func() renderers.Report {
+		etf := entities.NewETF("VIG")
+		etf.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2024, time.March, 28, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+			{PaymentDate: time.Date(2024, time.June, 27, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+			{PaymentDate: time.Date(2024, time.September, 26, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+			{PaymentDate: time.Date(2024, time.December, 20, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+			{PaymentDate: time.Date(2024, time.December, 27, 0, 0, 0, 0, time.UTC), Amount: 3.5},
+		})
+
+		return renderers.Report{ETFs: []*entities.ETF{etf}, CurrentYear: 2024, TotalYears: 1}
+	}
+
+	t.Run("should render the special payments of each year as CSV records", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().Render(&buffer, newFrequencyReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "VIG,2024,dividends,7.5\n")
+		assert.Contains(t, buffer.String(), "VIG,2024,special_dividends,3.5\n")
+		assert.Contains(t, buffer.String(), "VIG,Forward,dividends,4\n")
+	})
+
+	t.Run("should fill the payout frequency column with the detected cadence", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewMarkdownRenderer().Render(&buffer, newFrequencyReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "Quarterly (1 special)")
+	})
+}
+
+func TestRenderer_Risk(t *testing.T) {
+	t.Parallel()
+
+	newRiskReport := func() renderers.Report {
+		etf := entities.NewETF("SVOL")
+		etf.SetPriceBars([]entities.PriceBar{
+			{Date: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), Close: 100},
+			{Date: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC), Close: 110},
+			{Date: time.Date(2024, time.January, 4, 0, 0, 0, 0, time.UTC), Close: 99},
+			{Date: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC), Close: 108.9},
+		})
+
+		return renderers.Report{ETFs: []*entities.ETF{etf}, CurrentYear: 2024, TotalYears: 1}
+	}
+
+	t.Run("should render the risk metrics of the year and of the report span as CSV records", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().Render(&buffer, newRiskReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "SVOL,2024,volatility_percentage,")
+		assert.Contains(t, buffer.String(), "SVOL,2024,max_drawdown_percentage,-9.99")
+		assert.Contains(t, buffer.String(), "SVOL,2024-2024,sharpe_ratio,")
+		assert.Contains(t, buffer.String(), "SVOL,2024-2024,sortino_ratio,")
+	})
+
+	t.Run("should render the maximum drawdown with its peak and trough dates as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewJSONRenderer().Render(&buffer, newRiskReport())
+
+		// then
+		require.NoError(t, err)
+
+		var output struct {
+			ETFs []struct {
+				Risk struct {
+					MaxDrawdown struct {
+						Percentage float64 `json:"percentage"`
+						Peak       string  `json:"peak_date"`
+						Trough     string  `json:"trough_date"`
+					} `json:"max_drawdown"`
+					Sharpe *float64 `json:"sharpe_ratio"`
+				} `json:"risk"`
+			} `json:"etfs"`
+		}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+		require.Len(t, output.ETFs, 1)
+		assert.InDelta(t, -10.0, output.ETFs[0].Risk.MaxDrawdown.Percentage, 0.001)
+		assert.Equal(t, "2024-01-03", output.ETFs[0].Risk.MaxDrawdown.Peak)
+		assert.Equal(t, "2024-01-04", output.ETFs[0].Risk.MaxDrawdown.Trough)
+		assert.NotNil(t, output.ETFs[0].Risk.Sharpe)
+	})
+}
+
+func TestRenderer_RenderCorrelations(t *testing.T) {
+	t.Parallel()
+
+	newCorrelationReport := func() renderers.CorrelationReport {
+		return renderers.CorrelationReport{
+			Matrix: entities.CorrelationMatrix{
+				Names: []string{"SPY", "VOO"},
+				Correlations: [][]entities.Correlation{
+					{{Coefficient: 1, Observations: 250}, {Coefficient: 0.99, Observations: 250}},
+					{{Coefficient: 0.99, Observations: 250}, {}},
+				},
+			},
+			Threshold: 0.9,
+		}
+	}
+
+	t.Run("should render one CSV row and column per ETF", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewCSVRenderer().RenderCorrelations(&buffer, newCorrelationReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "etf,SPY,VOO\nSPY,1,0.99\nVOO,0.99,\n", buffer.String())
+	})
+
+	t.Run("should render the matrix and the pairs above the threshold as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewJSONRenderer().RenderCorrelations(&buffer, newCorrelationReport())
+
+		// then
+		require.NoError(t, err)
+
+		var output struct {
+			Matrix [][]*float64 `json:"matrix"`
+			Pairs  []struct {
+				First  string `json:"first"`
+				Second string `json:"second"`
+			} `json:"pairs_above_threshold"`
+		}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+		assert.Nil(t, output.Matrix[1][1])
+		require.Len(t, output.Pairs, 1)
+		assert.Equal(t, "VOO", output.Pairs[0].Second)
+	})
+
+	t.Run("should return an error for an unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		format := "xml"
+
+		// when
+		_, err := renderers.NewCorrelationRenderer(format)
+
+		// then
+		assert.ErrorIs(t, err, renderers.ErrUnknownFormat)
+	})
+}
+
+func TestRenderer_MarkdownRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render a Markdown table without ANSI color codes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+
+		// when
+		err := renderers.NewMarkdownRenderer().Render(&buffer, newReport())
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "| SPY Dividend Yields")
+		assert.Contains(t, buffer.String(), "1.000%")
+		assert.NotContains(t, buffer.String(), "\033[")
+	})
+}
+
+func TestRenderer_ShowSources(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the source row only when the report shows the sources", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		report := newReport()
+		report.ETFs[0].DividendSourcePerYear = map[string]string{"2025": "historyorg"}
+		report.ETFs[0].PriceSourcePerYear = map[string]string{"2025": "nasdaq", "2024": "nasdaq"}
+		var hidden, shown bytes.Buffer
+
+		// when
+		hiddenErr := renderers.NewMarkdownRenderer().Render(&hidden, report)
+		report.ShowSources = true
+		shownErr := renderers.NewMarkdownRenderer().Render(&shown, report)
+
+		// then
+		require.NoError(t, hiddenErr)
+		require.NoError(t, shownErr)
+		assert.NotContains(t, hidden.String(), "SPY Sources")
+		assert.Contains(t, shown.String(), "SPY Sources")
+		assert.Contains(t, shown.String(), "historyorg / nasdaq")
+	})
+}
@@ -0,0 +1,167 @@
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	// ansiGreen is the ANSI escape code for green foreground text.
+	ansiGreen = "\033[32m"
+
+	// ansiRed is the ANSI escape code for red foreground text.
+	ansiRed = "\033[31m"
+
+	// ansiYellow is the ANSI escape code for yellow foreground text.
+	ansiYellow = "\033[33m"
+
+	// ansiCyan is the ANSI escape code for cyan foreground text.
+	ansiCyan = "\033[36m"
+
+	// ansiReset is the ANSI escape code to reset text formatting.
+	ansiReset = "\033[0m"
+
+	// moderateCorrelation is the smallest correlation colored yellow in the heatmap, below the threshold.
+	moderateCorrelation = 0.5
+)
+
+// TableRenderer renders the report as an ANSI table with color-coded dividend yields.
+type TableRenderer struct {
+}
+
+func NewTableRenderer() *TableRenderer {
+	return &TableRenderer{}
+}
+
+func (r *TableRenderer) Render(writer io.Writer, report Report) error {
+	table := tablewriter.NewWriter(writer)
+	headers := headers(report)
+	table.Header(headers)
+
+	for _, etf := range report.ETFs {
+		dividendRow, closePriceRow, dividendYieldRow := etfRows(etf, report)
+
+		if err := table.Append(dividendRow); err != nil {
+			return fmt.Errorf("failed to append dividend row for ETF %s: %w", etf.Name, err)
+		}
+
+		if err := table.Append(closePriceRow); err != nil {
+			return fmt.Errorf("failed to append close price row for ETF %s: %w", etf.Name, err)
+		}
+
+		// Dividend yields with color-coded cells based on the target yield threshold. The current calendar
+		// year is still incomplete, so its color follows the trailing-twelve-month yield instead.
+		coloredYieldRow := applyColors(dividendYieldRow, report.TargetYieldPercentage)
+		if ttmYield, exists := etf.TTMYield(report.Now); exists && dividendYieldRow[1] != "-" {
+			coloredYieldRow[1] = colorize(dividendYieldRow[1], ttmYield, report.TargetYieldPercentage)
+		}
+
+		if err := table.Append(coloredYieldRow); err != nil {
+			return fmt.Errorf("failed to append dividend yield row for ETF %s: %w", etf.Name, err)
+		}
+
+		if err := table.Append(totalReturnRow(etf, report)); err != nil {
+			return fmt.Errorf("failed to append total return row for ETF %s: %w", etf.Name, err)
+		}
+
+		if report.ShowSources {
+			if err := table.Append(sourceRow(etf, report)); err != nil {
+				return fmt.Errorf("failed to append source row for ETF %s: %w", etf.Name, err)
+			}
+		}
+
+		// Add a separator row after the rows of every ETF.
+		separatorRow := make([]string, len(headers))
+		for i := range separatorRow {
+			separatorRow[i] = "-"
+		}
+
+		if err := table.Append(separatorRow); err != nil {
+			return fmt.Errorf("failed to append separator row: %w", err)
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
+
+// applyColors wraps each cell that contains a percentage value with the appropriate ANSI color code.
+// Cells with a dividend yield at or above the target threshold are colored green; below is red.
+func applyColors(row []string, targetYieldPercentage float64) []string {
+	colored := make([]string, len(row))
+
+	for i, cell := range row {
+		before, ok := strings.CutSuffix(cell, "%")
+		if !ok {
+			colored[i] = cell
+			continue
+		}
+
+		value, err := strconv.ParseFloat(before, 64)
+		if err != nil {
+			colored[i] = cell
+			continue
+		}
+
+		colored[i] = colorize(cell, value, targetYieldPercentage)
+	}
+
+	return colored
+}
+
+// colorize wraps the cell in green when the value is at or above the target threshold, and in red below it.
+func colorize(cell string, value, targetYieldPercentage float64) string {
+	if value >= targetYieldPercentage {
+		return ansiGreen + cell + ansiReset
+	}
+
+	return ansiRed + cell + ansiReset
+}
+
+// RenderCorrelations renders the correlation matrix as a heatmap: the pairs at or above the threshold are red, the
+// moderately correlated ones yellow, the weakly correlated ones green and the ones moving apart cyan.
+func (r *TableRenderer) RenderCorrelations(writer io.Writer, report CorrelationReport) error {
+	table := tablewriter.NewWriter(writer)
+	table.Header(correlationHeaders(report))
+
+	for i, name := range report.Matrix.Names {
+		row := correlationRow(report, i)
+
+		for j, correlation := range report.Matrix.Correlations[i] {
+			if i != j && correlation.Exists() {
+				row[j+1] = heatmapColor(correlation.Coefficient, report.Threshold) + row[j+1] + ansiReset
+			}
+		}
+
+		if err := table.Append(row); err != nil {
+			return fmt.Errorf("failed to append correlation row for ETF %s: %w", name, err)
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render the table: %w", err)
+	}
+
+	return nil
+}
+
+// heatmapColor returns the ANSI color of the correlation coefficient in the heatmap.
+func heatmapColor(coefficient, threshold float64) string {
+	switch {
+	case coefficient >= threshold:
+		return ansiRed
+	case coefficient >= moderateCorrelation:
+		return ansiYellow
+	case coefficient >= 0:
+		return ansiGreen
+	default:
+		return ansiCyan
+	}
+}
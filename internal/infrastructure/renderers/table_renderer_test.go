@@ -0,0 +1,162 @@
+package renderers
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render one block of rows per ETF with the year headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+		report := Report{
+			ETFs: []*entities.ETF{{
+				Name:                       "SPY",
+				AmountDividendsPerYear:     map[string]float64{"2025": 6.0},
+				AverageClosingPricePerYear: map[string]float64{"2025": 600.0},
+			}},
+			CurrentYear:           2025,
+			TotalYears:            2,
+			TargetYieldPercentage: 9,
+		}
+
+		// when
+		err := NewTableRenderer().Render(&buffer, report)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "2024")
+		assert.Contains(t, buffer.String(), "SPY Dividends")
+		assert.Contains(t, buffer.String(), ansiRed+"1.000%"+ansiReset)
+	})
+}
+
+func TestTableRenderer_RenderTTM(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should color the current year by the trailing-twelve-month yield", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+		etf := &entities.ETF{Name: "SPY"}
+		etf.SetDividends([]entities.Dividend{
+			{PaymentDate: time.Date(2024, time.September, 30, 0, 0, 0, 0, time.UTC), Amount: 5.0},
+			{PaymentDate: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Amount: 5.0},
+			{PaymentDate: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+		})
+		etf.SetPriceBars([]entities.PriceBar{{Date: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), Close: 100.0}})
+		report := Report{
+			ETFs:                  []*entities.ETF{etf},
+			Now:                   time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+			CurrentYear:           2025,
+			TotalYears:            2,
+			TargetYieldPercentage: 9,
+		}
+
+		// when
+		err := NewTableRenderer().Render(&buffer, report)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), ansiGreen+"1.000%"+ansiReset)
+		assert.Contains(t, buffer.String(), ansiGreen+"11.000%"+ansiReset)
+	})
+}
+
+func TestTableRenderer_ApplyColors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should apply green color to cells at or above the target yield and red below", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		row := []string{"10.00%", "5.00%", "15.00%"}
+
+		// when
+		result := applyColors(row, 9)
+
+		// then
+		expected := []string{
+			ansiGreen + "10.00%" + ansiReset,
+			ansiRed + "5.00%" + ansiReset,
+			ansiGreen + "15.00%" + ansiReset,
+		}
+		assert.Equal(t, expected, result, "they should be equal")
+	})
+
+	t.Run("should leave non-percentage cells unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		row := []string{"SPY", "$5.50", "N/A"}
+
+		// when
+		result := applyColors(row, 9)
+
+		// then
+		assert.Equal(t, row, result)
+	})
+}
+
+func TestTableRenderer_RenderCorrelations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should color the pairs by how closely they move together", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buffer bytes.Buffer
+		report := CorrelationReport{
+			Matrix: entities.CorrelationMatrix{
+				Names: []string{"SPY", "VOO", "GLD"},
+				Correlations: [][]entities.Correlation{
+					{{Coefficient: 1, Observations: 250}, {Coefficient: 0.99, Observations: 250}, {}},
+					{{Coefficient: 0.99, Observations: 250}, {Coefficient: 1, Observations: 250}, {
+						Coefficient: -0.12, Observations: 250,
+					}},
+					{{}, {Coefficient: -0.12, Observations: 250}, {Coefficient: 1, Observations: 250}},
+				},
+			},
+			Threshold: 0.9,
+		}
+
+		// when
+		err := NewTableRenderer().RenderCorrelations(&buffer, report)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), ansiRed+"0.99"+ansiReset)
+		assert.Contains(t, buffer.String(), ansiCyan+"-0.12"+ansiReset)
+		assert.NotContains(t, buffer.String(), ansiRed+"1.00")
+	})
+}
+
+func TestTableRenderer_HeatmapColor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should pick the band of the coefficient", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		coefficients := []float64{0.95, 0.7, 0.2, -0.3}
+
+		// when
+		var result []string
+		for _, coefficient := range coefficients {
+			result = append(result, heatmapColor(coefficient, 0.9))
+		}
+
+		// then
+		assert.Equal(t, []string{ansiRed, ansiYellow, ansiGreen, ansiCyan}, result)
+	})
+}
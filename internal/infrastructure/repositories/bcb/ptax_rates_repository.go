@@ -0,0 +1,138 @@
+package bcb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// DefaultBaseURL is the address of the open data API of the Brazilian central bank.
+	DefaultBaseURL = "https://olinda.bcb.gov.br"
+
+	// ptaxPath is the PTAX endpoint listing every bulletin of a currency in a period.
+	ptaxPath = "/olinda/servico/PTAX/versao/v1/odata/" +
+		"CotacaoMoedaPeriodo(moeda=@moeda,dataInicial=@dataInicial,dataFinalCotacao=@dataFinalCotacao)"
+
+	// queryDateLayout is the date format of the PTAX query parameters.
+	queryDateLayout = "01-02-2006"
+
+	// bulletinTimeLayout is the format of the moment of each bulletin.
+	bulletinTimeLayout = "2006-01-02 15:04:05.999"
+
+	// closingBulletin is the prefix of the daily closing bulletin, the one that sets the PTAX rate.
+	closingBulletin = "Fechamento"
+
+	// maximumRows is the number of bulletins requested at once, enough for many years of daily rates.
+	maximumRows = "100000"
+)
+
+// ErrUnsupportedPair is returned when neither currency of the pair is the Brazilian real.
+var ErrUnsupportedPair = errors.New("the PTAX rates are only quoted against BRL")
+
+// ErrUnexpectedStatus is returned when the central bank answers with a status code that is not successful.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// PTAXRatesRepository lists the PTAX rates, the daily reference rates of the Brazilian central bank.
+type PTAXRatesRepository struct {
+	baseURL string
+}
+
+func NewPTAXRatesRepository() *PTAXRatesRepository {
+	return &PTAXRatesRepository{baseURL: DefaultBaseURL}
+}
+
+func (r *PTAXRatesRepository) ListRatesByPair(
+	ctx context.Context,
+	base, quote string,
+	from, to time.Time,
+) ([]entities.FXRate, error) {
+	// The central bank quotes every currency in reais, so the rates from BRL are the inverse ones.
+	switch {
+	case quote == entities.CurrencyBRL:
+		return r.list(ctx, base, from, to, false)
+	case base == entities.CurrencyBRL:
+		return r.list(ctx, quote, from, to, true)
+	default:
+		return nil, fmt.Errorf("%w: %s/%s", ErrUnsupportedPair, base, quote)
+	}
+}
+
+// list requests the closing bulletins of the currency, inverting them when the rates from BRL are requested.
+func (r *PTAXRatesRepository) list(
+	ctx context.Context,
+	currency string,
+	from, to time.Time,
+	inverse bool,
+) ([]entities.FXRate, error) {
+	query := url.Values{
+		"@moeda":            {"'" + currency + "'"},
+		"@dataInicial":      {"'" + from.Format(queryDateLayout) + "'"},
+		"@dataFinalCotacao": {"'" + to.Format(queryDateLayout) + "'"},
+		"$top":              {maximumRows},
+		"$format":           {"json"},
+		"$select":           {"cotacaoVenda,dataHoraCotacao,tipoBoletim"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+ptaxPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("%w: %d for %s", ErrUnexpectedStatus, resp.StatusCode, currency)
+	}
+
+	var result struct {
+		Value []struct {
+			SellingRate float64 `json:"cotacaoVenda"`
+			Moment      string  `json:"dataHoraCotacao"`
+			Bulletin    string  `json:"tipoBoletim"`
+		} `json:"value"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	rates := make([]entities.FXRate, 0, len(result.Value))
+
+	for _, bulletin := range result.Value {
+		moment, parseErr := time.Parse(bulletinTimeLayout, bulletin.Moment)
+		if parseErr != nil || !strings.HasPrefix(bulletin.Bulletin, closingBulletin) || bulletin.SellingRate == 0 {
+			continue
+		}
+
+		rate := entities.FXRate{
+			Date:  time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC),
+			Base:  currency,
+			Quote: entities.CurrencyBRL,
+			Rate:  bulletin.SellingRate,
+		}
+
+		if inverse {
+			rate.Base, rate.Quote, rate.Rate = entities.CurrencyBRL, currency, 1/bulletin.SellingRate
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
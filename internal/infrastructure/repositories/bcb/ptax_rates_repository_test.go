@@ -0,0 +1,84 @@
+package bcb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "ptax_usd.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "'USD'", req.URL.Query().Get("@moeda"))
+		assert.Equal(t, "'01-02-2024'", req.URL.Query().Get("@dataInicial"))
+		_, _ = writer.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPTAXRatesRepository_ListRatesByPair(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should keep only the closing bulletin of each day", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := &PTAXRatesRepository{baseURL: newFixtureServer(t).URL}
+
+		// when
+		result, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, entities.FXRate{
+			Date: from, Base: "USD", Quote: "BRL", Rate: 4.8916,
+		}, result[0])
+	})
+
+	t.Run("should invert the rates from BRL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := &PTAXRatesRepository{baseURL: newFixtureServer(t).URL}
+
+		// when
+		result, err := repository.ListRatesByPair(context.Background(), "BRL", "USD", from, to)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, "BRL", result[0].Base)
+		assert.InDelta(t, 1/4.8916, result[0].Rate, 0.000001)
+	})
+
+	t.Run("should reject the pairs without BRL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewPTAXRatesRepository()
+
+		// when
+		_, err := repository.ListRatesByPair(context.Background(), "USD", "EUR", from, to)
+
+		// then
+		require.ErrorIs(t, err, ErrUnsupportedPair)
+	})
+}
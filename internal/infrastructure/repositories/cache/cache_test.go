@@ -0,0 +1,305 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPricesRepository struct {
+	data  []entities.PriceBar
+	err   error
+	calls int
+}
+
+func (s *stubPricesRepository) ListPriceBarsByETF(_ context.Context, _ string) ([]entities.PriceBar, error) {
+	s.calls++
+
+	return s.data, s.err
+}
+
+type stubSplitsRepository struct {
+	data []entities.Split
+}
+
+func (s *stubSplitsRepository) ListSplitsByETF(_ context.Context, _ string) ([]entities.Split, error) {
+	return s.data, nil
+}
+
+type stubFXRatesRepository struct {
+	calls []string
+}
+
+func (s *stubFXRatesRepository) ListRatesByPair(
+	_ context.Context,
+	base, quote string,
+	from, _ time.Time,
+) ([]entities.FXRate, error) {
+	s.calls = append(s.calls, base+quote)
+
+	return []entities.FXRate{{Date: from, Base: base, Quote: quote, Rate: 5}}, nil
+}
+
+func day(year int, month time.Month, date int) time.Time {
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestStore(t *testing.T, now time.Time) *Store {
+	t.Helper()
+
+	store := NewStore(t.TempDir())
+	store.now = func() time.Time { return now }
+
+	return store
+}
+
+func TestCache_CachedPricesRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should serve the cached bars while the entry is younger than the TTL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubPricesRepository{data: []entities.PriceBar{{Date: day(2025, time.May, 30), Close: 100}}}
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, false)
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("should keep the closed years from the cache and refresh the current year once expired", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubPricesRepository{data: []entities.PriceBar{
+			{Date: day(2024, time.December, 31), Close: 90},
+			{Date: day(2025, time.May, 30), Close: 100},
+		}}
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, false)
+		_, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2025, time.June, 2) }
+		inner.data = []entities.PriceBar{
+			{Date: day(2024, time.December, 31), Close: 1},
+			{Date: day(2025, time.June, 1), Close: 110},
+		}
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.calls)
+		assert.Equal(t, []entities.PriceBar{
+			{Date: day(2024, time.December, 31), Close: 90},
+			{Date: day(2025, time.June, 1), Close: 110},
+		}, result)
+	})
+
+	t.Run("should refresh the year still open when the entry was fetched across New Year", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.December, 15))
+		inner := &stubPricesRepository{data: []entities.PriceBar{{Date: day(2025, time.December, 12), Close: 100}}}
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, false)
+		_, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2026, time.January, 10) }
+		inner.data = []entities.PriceBar{
+			{Date: day(2025, time.December, 12), Close: 100},
+			{Date: day(2025, time.December, 30), Close: 105},
+			{Date: day(2026, time.January, 9), Close: 110},
+		}
+		_, err = repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2026, time.February, 1) }
+		inner.data = []entities.PriceBar{{Date: day(2026, time.January, 30), Close: 120}}
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.calls)
+		assert.Equal(t, []entities.PriceBar{
+			{Date: day(2025, time.December, 12), Close: 100},
+			{Date: day(2025, time.December, 30), Close: 105},
+			{Date: day(2026, time.January, 30), Close: 120},
+		}, result)
+	})
+
+	t.Run("should serve the stale entry when fetching fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubPricesRepository{data: []entities.PriceBar{{Date: day(2025, time.May, 30), Close: 100}}}
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, false)
+		_, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2025, time.June, 2) }
+		inner.err = errors.New("network error")
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestCache_CachedFXRatesRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should cache the rates of each currency pair on its own", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubFXRatesRepository{}
+		repository := NewCachedFXRatesRepository(inner, store, "ptax-5y", time.Hour, false)
+		from, to := day(2020, time.January, 1), day(2025, time.June, 1)
+
+		// when
+		_, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+		require.NoError(t, err)
+		_, err = repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+		require.NoError(t, err)
+		result, err := repository.ListRatesByPair(context.Background(), "BRL", "USD", from, to)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"USDBRL", "BRLUSD"}, inner.calls)
+		assert.Equal(t, []entities.FXRate{{Date: from, Base: "BRL", Quote: "USD", Rate: 5}}, result)
+	})
+}
+
+func TestCache_CachedSplitsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should remove the cached prices of the ticker when a new split is fetched", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		prices := &stubPricesRepository{data: []entities.PriceBar{
+			{Date: day(2024, time.December, 31), Close: 90, SplitAdjusted: true},
+		}}
+		pricesRepository := NewCachedPricesRepository(prices, store, "yahoo", time.Hour, false)
+		_, err := pricesRepository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		splits := &stubSplitsRepository{}
+		splitsRepository := NewCachedSplitsRepository(splits, store, "yahoo", time.Hour, false)
+		_, err = splitsRepository.ListSplitsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2025, time.June, 2) }
+		splits.data = []entities.Split{{Date: day(2025, time.June, 1), Numerator: 2, Denominator: 1}}
+		prices.data = []entities.PriceBar{{Date: day(2024, time.December, 31), Close: 45, SplitAdjusted: true}}
+
+		// when
+		_, err = splitsRepository.ListSplitsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+		result, err := pricesRepository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, prices.calls)
+		assert.Equal(t, []entities.PriceBar{
+			{Date: day(2024, time.December, 31), Close: 45, SplitAdjusted: true},
+		}, result)
+	})
+
+	t.Run("should keep the cached prices while the splits are the same", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		prices := &stubPricesRepository{data: []entities.PriceBar{{Date: day(2024, time.December, 31), Close: 90}}}
+		pricesRepository := NewCachedPricesRepository(prices, store, "nasdaq", 48*time.Hour, false)
+		_, err := pricesRepository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		splits := &stubSplitsRepository{data: []entities.Split{
+			{Date: day(2020, time.June, 1), Numerator: 2, Denominator: 1},
+		}}
+		splitsRepository := NewCachedSplitsRepository(splits, store, "yahoo", time.Hour, false)
+		_, err = splitsRepository.ListSplitsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2025, time.June, 2) }
+
+		// when
+		_, err = splitsRepository.ListSplitsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+		_, err = pricesRepository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, prices.calls)
+	})
+}
+
+func TestCache_Offline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should serve expired entries without fetching", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubPricesRepository{data: []entities.PriceBar{{Date: day(2025, time.May, 30), Close: 100}}}
+		_, err := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, false).
+			ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return day(2026, time.June, 1) }
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, true)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("should report the entries missing from the cache", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		store := newTestStore(t, day(2025, time.June, 1))
+		inner := &stubPricesRepository{}
+		repository := NewCachedPricesRepository(inner, store, "nasdaq", time.Hour, true)
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "QQQ")
+
+		// then
+		require.ErrorIs(t, err, ErrNotCached)
+		assert.ErrorContains(t, err, "prices for QQQ")
+		assert.Equal(t, 0, inner.calls)
+	})
+}
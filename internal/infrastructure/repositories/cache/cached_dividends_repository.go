@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// kindDividends is the cache directory of the dividend payments.
+const kindDividends = "dividends"
+
+// CachedDividendsRepository decorates a DividendsRepository with the on-disk cache.
+type CachedDividendsRepository struct {
+	inner  repositories.DividendsRepository
+	policy policy
+}
+
+func NewCachedDividendsRepository(
+	inner repositories.DividendsRepository,
+	store *Store,
+	namespace string,
+	ttl time.Duration,
+	offline bool,
+) *CachedDividendsRepository {
+	return &CachedDividendsRepository{
+		inner:  inner,
+		policy: policy{store: store, kind: kindDividends, namespace: namespace, ttl: ttl, offline: offline},
+	}
+}
+
+func (r *CachedDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
+	return fetchThrough(ctx, r.policy, etf, r.inner.ListDividendsByETF,
+		func(cached, fresh []entities.Dividend, fetchedAt time.Time) []entities.Dividend {
+			return mergeByYear(cached, fresh, func(dividend entities.Dividend) int {
+				return dividend.Date().Year()
+			}, fetchedAt)
+		},
+	)
+}
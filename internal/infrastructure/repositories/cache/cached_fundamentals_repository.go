@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// kindFundamentals is the cache directory of the fundamentals.
+const kindFundamentals = "fundamentals"
+
+// CachedFundamentalsRepository decorates a FundamentalsRepository with the on-disk cache.
+type CachedFundamentalsRepository struct {
+	inner  repositories.FundamentalsRepository
+	policy policy
+}
+
+func NewCachedFundamentalsRepository(
+	inner repositories.FundamentalsRepository,
+	store *Store,
+	namespace string,
+	ttl time.Duration,
+	offline bool,
+) *CachedFundamentalsRepository {
+	return &CachedFundamentalsRepository{
+		inner:  inner,
+		policy: policy{store: store, kind: kindFundamentals, namespace: namespace, ttl: ttl, offline: offline},
+	}
+}
+
+func (r *CachedFundamentalsRepository) GetFundamentalsByETF(
+	ctx context.Context,
+	etf string,
+) (entities.Fundamentals, error) {
+	// The fundamentals are a snapshot, so the fresh response always replaces the cached one.
+	return fetchThrough(ctx, r.policy, etf, r.inner.GetFundamentalsByETF,
+		func(_, fresh entities.Fundamentals, _ time.Time) entities.Fundamentals {
+			return fresh
+		},
+	)
+}
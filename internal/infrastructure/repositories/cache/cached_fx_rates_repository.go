@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// kindFXRates is the cache directory of the daily exchange rates.
+const kindFXRates = "fx"
+
+// CachedFXRatesRepository decorates an FXRatesRepository with the on-disk cache, keyed by currency pair.
+type CachedFXRatesRepository struct {
+	inner  repositories.FXRatesRepository
+	policy policy
+}
+
+func NewCachedFXRatesRepository(
+	inner repositories.FXRatesRepository,
+	store *Store,
+	namespace string,
+	ttl time.Duration,
+	offline bool,
+) *CachedFXRatesRepository {
+	return &CachedFXRatesRepository{
+		inner:  inner,
+		policy: policy{store: store, kind: kindFXRates, namespace: namespace, ttl: ttl, offline: offline},
+	}
+}
+
+func (r *CachedFXRatesRepository) ListRatesByPair(
+	ctx context.Context,
+	base, quote string,
+	from, to time.Time,
+) ([]entities.FXRate, error) {
+	return fetchThrough(ctx, r.policy, base+"-"+quote,
+		func(ctx context.Context, _ string) ([]entities.FXRate, error) {
+			return r.inner.ListRatesByPair(ctx, base, quote, from, to)
+		},
+		func(cached, fresh []entities.FXRate, fetchedAt time.Time) []entities.FXRate {
+			return mergeByYear(cached, fresh, func(rate entities.FXRate) int {
+				return rate.Date.Year()
+			}, fetchedAt)
+		},
+	)
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// kindPrices is the cache directory of the daily price bars.
+const kindPrices = "prices"
+
+// CachedPricesRepository decorates a PricesRepository with the on-disk cache.
+type CachedPricesRepository struct {
+	inner  repositories.PricesRepository
+	policy policy
+}
+
+func NewCachedPricesRepository(
+	inner repositories.PricesRepository,
+	store *Store,
+	namespace string,
+	ttl time.Duration,
+	offline bool,
+) *CachedPricesRepository {
+	return &CachedPricesRepository{
+		inner:  inner,
+		policy: policy{store: store, kind: kindPrices, namespace: namespace, ttl: ttl, offline: offline},
+	}
+}
+
+func (r *CachedPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
+	return fetchThrough(ctx, r.policy, etf, r.inner.ListPriceBarsByETF,
+		func(cached, fresh []entities.PriceBar, fetchedAt time.Time) []entities.PriceBar {
+			return mergeByYear(cached, fresh, func(bar entities.PriceBar) int {
+				return bar.Date.Year()
+			}, fetchedAt)
+		},
+	)
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+	logger "github.com/sirupsen/logrus"
+)
+
+// kindSplits is the cache directory of the share splits.
+const kindSplits = "splits"
+
+// CachedSplitsRepository decorates a SplitsRepository with the on-disk cache.
+type CachedSplitsRepository struct {
+	inner  repositories.SplitsRepository
+	policy policy
+}
+
+func NewCachedSplitsRepository(
+	inner repositories.SplitsRepository,
+	store *Store,
+	namespace string,
+	ttl time.Duration,
+	offline bool,
+) *CachedSplitsRepository {
+	return &CachedSplitsRepository{
+		inner:  inner,
+		policy: policy{store: store, kind: kindSplits, namespace: namespace, ttl: ttl, offline: offline},
+	}
+}
+
+func (r *CachedSplitsRepository) ListSplitsByETF(ctx context.Context, etf string) ([]entities.Split, error) {
+	// The providers always answer with the whole history, so the fresh response replaces the cached one. The cached
+	// prices and dividends of the closed years are never fetched again, and the ones a provider had already restated
+	// in the share terms of that time would miss a new split, so they are removed to be fetched whole.
+	return fetchThrough(ctx, r.policy, etf, r.inner.ListSplitsByETF,
+		func(cached, fresh []entities.Split, _ time.Time) []entities.Split {
+			if !slices.EqualFunc(cached, fresh, sameSplit) {
+				if err := r.policy.store.invalidate(etf, kindPrices, kindDividends); err != nil {
+					logger.WithError(err).Warnf("Failed to remove the cached prices and dividends after a new split for: %s", etf)
+				}
+			}
+
+			return fresh
+		},
+	)
+}
+
+// sameSplit reports whether both splits happened on the same day at the same ratio.
+func sameSplit(first, second entities.Split) bool {
+	return first.Date.Equal(second.Date) && first.Ratio() == second.Ratio()
+}
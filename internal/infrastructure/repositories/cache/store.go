@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// appDirectory is the directory created under the user cache directory.
+	appDirectory = "investmate"
+
+	// directoryPermissions are the permissions of the cache directories.
+	directoryPermissions = 0o755
+
+	// filePermissions are the permissions of the cache files.
+	filePermissions = 0o600
+)
+
+// ErrNotCached is returned in offline mode when the requested data was never cached.
+var ErrNotCached = errors.New("not cached")
+
+// entry is the content of a cache file.
+type entry[T any] struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Records   T         `json:"records"`
+}
+
+// Store persists the cached responses as JSON files, one per kind, namespace and key.
+type Store struct {
+	directory string
+	now       func() time.Time
+}
+
+func NewStore(directory string) *Store {
+	return &Store{directory: directory, now: time.Now}
+}
+
+// DefaultDirectory returns the cache directory under the user cache directory.
+func DefaultDirectory() (string, error) {
+	directory, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the user cache directory: %w", err)
+	}
+
+	return filepath.Join(directory, appDirectory), nil
+}
+
+// path returns the file that stores the entry of the key.
+func (s *Store) path(kind, namespace, key string) string {
+	return filepath.Join(s.directory, kind, namespace, strings.ToUpper(key)+".json")
+}
+
+// read loads the entry of the key, reporting whether it exists.
+func read[T any](s *Store, kind, namespace, key string) (entry[T], bool, error) {
+	var cached entry[T]
+
+	content, err := os.ReadFile(s.path(kind, namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return cached, false, nil
+	}
+
+	if err != nil {
+		return cached, false, fmt.Errorf("failed to read the cache file: %w", err)
+	}
+
+	if err = json.Unmarshal(content, &cached); err != nil {
+		return cached, false, fmt.Errorf("failed to decode the cache file: %w", err)
+	}
+
+	return cached, true, nil
+}
+
+// write persists the entry of the key.
+func write[T any](s *Store, kind, namespace, key string, cached entry[T]) error {
+	path := s.path(kind, namespace, key)
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		return fmt.Errorf("failed to create the cache directory: %w", err)
+	}
+
+	content, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode the cache file: %w", err)
+	}
+
+	if err = os.WriteFile(path, content, filePermissions); err != nil {
+		return fmt.Errorf("failed to write the cache file: %w", err)
+	}
+
+	return nil
+}
+
+// invalidate removes the entries of the key in every namespace of the kinds, so they are fetched again.
+func (s *Store) invalidate(key string, kinds ...string) error {
+	for _, kind := range kinds {
+		paths, err := filepath.Glob(filepath.Join(s.directory, kind, "*", strings.ToUpper(key)+".json"))
+		if err != nil {
+			return fmt.Errorf("failed to list the cache files: %w", err)
+		}
+
+		for _, path := range paths {
+			if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove the cache file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// policy describes how a repository uses the store.
+type policy struct {
+	store     *Store
+	kind      string
+	namespace string
+	ttl       time.Duration
+	offline   bool
+}
+
+// fetchThrough serves the key from the store while the entry is younger than the TTL, and otherwise fetches it
+// and merges the fresh records into the cached ones. When fetching fails the cached records are served instead,
+// and in offline mode nothing is ever fetched.
+func fetchThrough[T any](
+	ctx context.Context,
+	p policy,
+	key string,
+	fetch func(ctx context.Context, key string) (T, error),
+	merge func(cached, fresh T, fetchedAt time.Time) T,
+) (T, error) {
+	cached, exists, err := read[T](p.store, p.kind, p.namespace, key)
+	if err != nil {
+		logger.WithError(err).Warnf("Ignoring the cached %s for: %s", p.kind, key)
+	}
+
+	now := p.store.now()
+
+	if p.offline {
+		if !exists {
+			var empty T
+			return empty, fmt.Errorf("%w: %s for %s", ErrNotCached, p.kind, key)
+		}
+
+		return cached.Records, nil
+	}
+
+	if exists && now.Sub(cached.FetchedAt) < p.ttl {
+		return cached.Records, nil
+	}
+
+	fresh, err := fetch(ctx, key)
+	if err != nil {
+		if exists {
+			logger.WithError(err).Warnf("Serving the stale cached %s for: %s", p.kind, key)
+			return cached.Records, nil
+		}
+
+		return fresh, err
+	}
+
+	if exists {
+		fresh = merge(cached.Records, fresh, cached.FetchedAt)
+	}
+
+	if err = write(p.store, p.kind, p.namespace, key, entry[T]{FetchedAt: now, Records: fresh}); err != nil {
+		logger.WithError(err).Warnf("Failed to cache the %s for: %s", p.kind, key)
+	}
+
+	return fresh, nil
+}
+
+// mergeByYear keeps the cached records of the years that were already closed when they were fetched, which never
+// change, and takes the records of the later years, and of any closed year missing from the cache, from the fresh
+// response. A year still open at the time of the fetch may have been cached only partially, so it is fetched again.
+func mergeByYear[R any](cached, fresh []R, year func(R) int, fetchedAt time.Time) []R {
+	cachedYears := make(map[int]bool)
+	merged := make([]R, 0, len(fresh))
+
+	for _, record := range cached {
+		if year(record) < fetchedAt.Year() {
+			cachedYears[year(record)] = true
+			merged = append(merged, record)
+		}
+	}
+
+	for _, record := range fresh {
+		if !cachedYears[year(record)] {
+			merged = append(merged, record)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,169 @@
+package csvholdings
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// tickerColumn is the header of the column with the ticker of the position.
+	tickerColumn = "ticker"
+
+	// sharesColumn is the header of the column with the number of shares of the position.
+	sharesColumn = "shares"
+
+	// costBasisColumn is the header of the column with the total paid for the shares.
+	costBasisColumn = "cost_basis"
+
+	// accountColumn is the header of the optional column with the account holding the shares.
+	accountColumn = "account"
+
+	// byteOrderMark starts the files exported by some spreadsheets.
+	byteOrderMark = "\uFEFF"
+)
+
+var (
+	// ErrMissingColumn is returned when the CSV header lacks the ticker, the shares or the cost basis column.
+	ErrMissingColumn = errors.New("missing column")
+
+	// ErrInvalidHolding is returned when a row has no ticker or its shares or cost basis cannot be parsed.
+	ErrInvalidHolding = errors.New("invalid holding")
+
+	// errEmptyTicker is returned when a row of the holdings file has no ticker.
+	errEmptyTicker = errors.New("the ticker is empty")
+)
+
+// Options describes where the holdings file lives and how to read it. Zero values fall back to comma-separated rows.
+type Options struct {
+	Path         string
+	Delimiter    rune
+	DecimalComma bool // Whether the shares and the cost basis use a comma as the decimal separator.
+}
+
+type CSVHoldingsRepository struct {
+	options Options
+}
+
+func NewCSVHoldingsRepository(options Options) *CSVHoldingsRepository {
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+
+	return &CSVHoldingsRepository{options: options}
+}
+
+func (r *CSVHoldingsRepository) ListHoldings(_ context.Context) ([]entities.Holding, error) {
+	file, err := os.Open(r.options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the holdings file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return r.parse(file)
+}
+
+// parse reads every holding of the file. Unlike the price and rate files, a row that cannot be parsed is an error,
+// because skipping it would silently leave a position out of the portfolio.
+func (r *CSVHoldingsRepository) parse(reader io.Reader) ([]entities.Holding, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = r.options.Delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header of %s: %w", r.options.Path, err)
+	}
+
+	columns := map[string]int{tickerColumn: -1, sharesColumn: -1, costBasisColumn: -1, accountColumn: -1}
+	for index, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, byteOrderMark)))
+		if _, known := columns[name]; known {
+			columns[name] = index
+		}
+	}
+
+	if columns[tickerColumn] == -1 || columns[sharesColumn] == -1 || columns[costBasisColumn] == -1 {
+		return nil, fmt.Errorf(
+			"%w in %s: %s, %s and %s are required",
+			ErrMissingColumn, r.options.Path, tickerColumn, sharesColumn, costBasisColumn,
+		)
+	}
+
+	var holdings []entities.Holding
+
+	for {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read the CSV rows of %s: %w", r.options.Path, readErr)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+
+		holding, holdingErr := r.holding(record, columns)
+		if holdingErr != nil {
+			return nil, fmt.Errorf("%w at line %d of %s: %w", ErrInvalidHolding, line, r.options.Path, holdingErr)
+		}
+
+		holdings = append(holdings, holding)
+	}
+
+	return holdings, nil
+}
+
+// holding converts a row into a holding.
+func (r *CSVHoldingsRepository) holding(record []string, columns map[string]int) (entities.Holding, error) {
+	ticker := strings.ToUpper(field(record, columns[tickerColumn]))
+	if ticker == "" {
+		return entities.Holding{}, errEmptyTicker
+	}
+
+	shares, err := r.number(field(record, columns[sharesColumn]))
+	if err != nil {
+		return entities.Holding{}, fmt.Errorf("failed to parse the shares: %w", err)
+	}
+
+	costBasis, err := r.number(field(record, columns[costBasisColumn]))
+	if err != nil {
+		return entities.Holding{}, fmt.Errorf("failed to parse the cost basis: %w", err)
+	}
+
+	return entities.Holding{
+		Ticker:    ticker,
+		Account:   field(record, columns[accountColumn]),
+		Shares:    shares,
+		CostBasis: costBasis,
+	}, nil
+}
+
+// field returns the trimmed value of the column, or an empty string when the row or the file lacks it.
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+// number parses a quantity, honoring the decimal separator of the file.
+func (r *CSVHoldingsRepository) number(value string) (float64, error) {
+	if r.options.DecimalComma {
+		value = strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", ".")
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
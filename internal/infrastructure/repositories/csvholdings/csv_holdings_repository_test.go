@@ -0,0 +1,81 @@
+package csvholdings
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVHoldingsRepository_ListHoldings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read every holding with the headers in any case", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVHoldingsRepository(Options{Path: filepath.Join("testdata", "holdings.csv")})
+
+		// when
+		result, err := repository.ListHoldings(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Holding{
+			{Ticker: "SCHD", Account: "IRA", Shares: 100, CostBasis: 2000},
+			{Ticker: "JEPI", Account: "Taxable", Shares: 30.5, CostBasis: 1500},
+			{Ticker: "SCHD", Shares: 20, CostBasis: 500},
+		}, result)
+	})
+
+	t.Run("should read a spreadsheet export with the decimal comma and without the account column", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVHoldingsRepository(Options{
+			Path:         filepath.Join("testdata", "carteira.csv"),
+			Delimiter:    ';',
+			DecimalComma: true,
+		})
+
+		// when
+		result, err := repository.ListHoldings(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Holding{{Ticker: "MXRF11", Shares: 1000, CostBasis: 10250.5}}, result)
+	})
+
+	t.Run("should return an error when a row cannot be parsed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVHoldingsRepository(Options{Path: filepath.Join("testdata", "invalid.csv")})
+
+		// when
+		_, err := repository.ListHoldings(context.Background())
+
+		// then
+		require.ErrorIs(t, err, ErrInvalidHolding)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("should return an error when the cost basis column is missing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVHoldingsRepository(Options{
+			Path:      filepath.Join("testdata", "carteira.csv"),
+			Delimiter: ',',
+		})
+
+		// when
+		_, err := repository.ListHoldings(context.Background())
+
+		// then
+		require.ErrorIs(t, err, ErrMissingColumn)
+	})
+}
@@ -0,0 +1,256 @@
+package csvprices
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// tickerPlaceholder is replaced with the ticker in upper case in the source.
+	tickerPlaceholder = "{ticker}"
+
+	// lowerTickerPlaceholder is replaced with the ticker in lower case in the source, as Stooq expects.
+	lowerTickerPlaceholder = "{ticker_lower}"
+
+	// byteOrderMark starts the files exported by some spreadsheets and brokers.
+	byteOrderMark = "\uFEFF"
+
+	// bodyExcerptLength is the number of bytes of an error response body kept in the returned error.
+	bodyExcerptLength = 256
+)
+
+var (
+	// ErrMissingColumn is returned when the CSV header lacks the date or the close column.
+	ErrMissingColumn = errors.New("missing column")
+
+	// ErrUnexpectedStatus is returned when the source URL answers with a status code that is not successful.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+)
+
+// Columns maps each bar field to the header of its CSV column. Empty names fall back to the Stooq headers.
+type Columns struct {
+	Date   string
+	Open   string
+	High   string
+	Low    string
+	Close  string
+	Volume string
+}
+
+// Options describes where the CSV price history lives and how to read it. Zero values fall back to the Stooq
+// format: comma-separated, ISO dates and the Date, Open, High, Low, Close and Volume headers.
+type Options struct {
+	Source        string // URL template or local path template, with the {ticker} or {ticker_lower} placeholders.
+	DateFormat    string // Go layout of the dates, such as "2006-01-02" or "01/02/2006".
+	Delimiter     rune
+	DecimalComma  bool // Whether the numbers use a comma as the decimal separator, as in European exports.
+	Columns       Columns
+	HTTPClient    *http.Client
+	SplitAdjusted bool // Whether the source is already split-adjusted, so the bars are not divided a second time.
+}
+
+type CSVPricesRepository struct {
+	options      Options
+	yearsToFetch int
+	now          func() time.Time
+}
+
+func NewCSVPricesRepository(options Options, yearsToFetch int) *CSVPricesRepository {
+	if options.DateFormat == "" {
+		options.DateFormat = time.DateOnly
+	}
+
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+
+	options.Columns = Columns{
+		Date:   cmp.Or(options.Columns.Date, "Date"),
+		Open:   cmp.Or(options.Columns.Open, "Open"),
+		High:   cmp.Or(options.Columns.High, "High"),
+		Low:    cmp.Or(options.Columns.Low, "Low"),
+		Close:  cmp.Or(options.Columns.Close, "Close"),
+		Volume: cmp.Or(options.Columns.Volume, "Volume"),
+	}
+
+	return &CSVPricesRepository{options: options, yearsToFetch: yearsToFetch, now: time.Now}
+}
+
+// IsLocal reports whether the source is a local file, which needs neither the network nor the cache.
+func (r *CSVPricesRepository) IsLocal() bool {
+	return !strings.HasPrefix(r.options.Source, "http://") && !strings.HasPrefix(r.options.Source, "https://")
+}
+
+func (r *CSVPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
+	source := strings.NewReplacer(
+		tickerPlaceholder, strings.ToUpper(etf),
+		lowerTickerPlaceholder, strings.ToLower(etf),
+	).Replace(r.options.Source)
+
+	reader, err := r.open(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return r.parse(reader, source)
+}
+
+// open returns the content of the local file or of the URL.
+func (r *CSVPricesRepository) open(ctx context.Context, source string) (io.ReadCloser, error) {
+	if r.IsLocal() {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the CSV file: %w", err)
+		}
+
+		return file, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := r.options.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, bodyExcerptLength))
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %d from %s: %s", ErrUnexpectedStatus, resp.StatusCode, source, excerpt)
+	}
+
+	return resp.Body, nil
+}
+
+// parse reads the bars of the fetched years, skipping the rows whose date or close price cannot be parsed.
+func (r *CSVPricesRepository) parse(reader io.Reader, source string) ([]entities.PriceBar, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = r.options.Delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header of %s: %w", source, err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for index, name := range header {
+		indexes[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, byteOrderMark)))] = index
+	}
+
+	columns := r.options.Columns
+	column := func(name string) int {
+		if index, exists := indexes[strings.ToLower(name)]; exists {
+			return index
+		}
+
+		return -1
+	}
+
+	dateIndex, closeIndex := column(columns.Date), column(columns.Close)
+	if dateIndex == -1 || closeIndex == -1 {
+		return nil, fmt.Errorf("%w in %s: %s and %s are required", ErrMissingColumn, source, columns.Date, columns.Close)
+	}
+
+	openIndex, highIndex, lowIndex, volumeIndex := column(columns.Open), column(columns.High),
+		column(columns.Low), column(columns.Volume)
+	firstYear := r.now().Year() - r.yearsToFetch
+
+	var bars []entities.PriceBar
+
+	for {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read the CSV rows of %s: %w", source, readErr)
+		}
+
+		date, dateErr := time.Parse(r.options.DateFormat, field(record, dateIndex))
+		closePrice, closeErr := ParseNumber(field(record, closeIndex), r.options.DecimalComma)
+		if dateErr != nil || closeErr != nil || date.Year() < firstYear {
+			continue
+		}
+
+		bars = append(bars, entities.PriceBar{
+			Date:   date,
+			Open:   r.numberOrZero(field(record, openIndex)),
+			High:   r.numberOrZero(field(record, highIndex)),
+			Low:    r.numberOrZero(field(record, lowIndex)),
+			Close:  closePrice,
+			Volume: r.numberOrZero(field(record, volumeIndex)),
+
+			SplitAdjusted: r.options.SplitAdjusted,
+		})
+	}
+
+	return bars, nil
+}
+
+// ParseNumber parses a number of a CSV export, such as a price, a volume or an exchange rate, ignoring the spaces, the
+// currency symbol before the number, such as "$" or "R$", and the thousands separators.
+func ParseNumber(value string, decimalComma bool) (float64, error) {
+	value = strings.Join(strings.Fields(value), "")
+
+	sign := ""
+	if rest, negative := strings.CutPrefix(value, "-"); negative {
+		sign, value = "-", rest
+	}
+
+	value = sign + strings.TrimLeftFunc(value, func(char rune) bool {
+		return !unicode.IsDigit(char) && !strings.ContainsRune("+-.,", char)
+	})
+
+	if decimalComma {
+		value = strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// numberOrZero parses the number of an optional column, using zero when it is missing or invalid.
+func (r *CSVPricesRepository) numberOrZero(value string) float64 {
+	number, err := ParseNumber(value, r.options.DecimalComma)
+	if err != nil {
+		return 0
+	}
+
+	return number
+}
+
+// field returns the trimmed value at the index, or an empty string when the column does not exist.
+func field(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
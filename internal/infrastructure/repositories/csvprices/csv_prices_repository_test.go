@@ -0,0 +1,198 @@
+package csvprices
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(options Options, yearsToFetch int) *CSVPricesRepository {
+	repository := NewCSVPricesRepository(options, yearsToFetch)
+	repository.now = func() time.Time { return time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC) }
+
+	return repository
+}
+
+func TestCSVPricesRepository_ListPriceBarsByETF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read a Stooq file from the local path template and skip the years not fetched", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := newTestRepository(Options{Source: filepath.Join("testdata", "{ticker_lower}.us.csv")}, 1)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.True(t, repository.IsLocal())
+		assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), result[0].Date)
+		assert.InDelta(t, 472.65, result[0].Close, 0.001)
+		assert.InDelta(t, 123623700, result[0].Volume, 0.001)
+	})
+
+	t.Run("should map the columns, date format and decimal comma of a broker export", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := newTestRepository(Options{
+			Source:       filepath.Join("testdata", "broker_export.csv"),
+			DateFormat:   "02/01/2006",
+			Delimiter:    ';',
+			DecimalComma: true,
+			Columns:      Columns{Date: "Data", Open: "Abertura", Close: "Fechamento", Volume: "Volume"},
+		}, 5)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "BOVA11")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), result[0].Date)
+		assert.InDelta(t, 100.10, result[0].Open, 0.001)
+		assert.InDelta(t, 101.50, result[0].Close, 0.001)
+		assert.InDelta(t, 1234567, result[0].Volume, 0.001)
+		assert.Zero(t, result[0].High)
+	})
+
+	t.Run("should strip the R$ symbol from the prices of a broker export with decimal comma", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := newTestRepository(Options{
+			Source:       filepath.Join("testdata", "broker_export_brl.csv"),
+			DateFormat:   "02/01/2006",
+			Delimiter:    ';',
+			DecimalComma: true,
+			Columns:      Columns{Date: "Data", Open: "Abertura", Close: "Fechamento", Volume: "Volume"},
+		}, 5)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "BOVA11")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.InDelta(t, 1230.00, result[0].Open, 0.001)
+		assert.InDelta(t, 1234.56, result[0].Close, 0.001)
+		assert.InDelta(t, 12.34, result[1].Close, 0.001)
+		assert.InDelta(t, 2500, result[1].Volume, 0.001)
+	})
+
+	t.Run("should download the CSV from the URL template", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content, err := os.ReadFile(filepath.Join("testdata", "spy.us.csv"))
+		require.NoError(t, err)
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "spy.us", req.URL.Query().Get("s"))
+			_, _ = writer.Write(content)
+		}))
+		defer server.Close()
+
+		repository := newTestRepository(Options{
+			Source:     server.URL + "/q/d/l/?s={ticker_lower}.us&i=d",
+			HTTPClient: server.Client(),
+		}, 2)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result, 4)
+		assert.False(t, repository.IsLocal())
+	})
+
+	t.Run("should return an error when the close column is missing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := newTestRepository(Options{
+			Source:  filepath.Join("testdata", "spy.us.csv"),
+			Columns: Columns{Close: "Adj Close"},
+		}, 1)
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.ErrorIs(t, err, ErrMissingColumn)
+	})
+}
+
+func TestCSVPrices_ParseNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value        string
+		decimalComma bool
+		expected     float64
+	}{
+		{value: "$1,234.50", expected: 1234.5},
+		{value: "-$1.50", expected: -1.5},
+		{value: "US$ 10.00", expected: 10},
+		{value: "R$ 12,34", decimalComma: true, expected: 12.34},
+		{value: "-R$ 1.234,56", decimalComma: true, expected: -1234.56},
+		{value: "R$\u00a012,34", decimalComma: true, expected: 12.34},
+	}
+
+	for _, test := range tests {
+		t.Run("should parse "+test.value, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			// the value of the test
+
+			// when
+			result, err := ParseNumber(test.value, test.decimalComma)
+
+			// then
+			require.NoError(t, err)
+			assert.InDelta(t, test.expected, result, 0.001)
+		})
+	}
+}
+
+func TestCSVPricesRepository_SplitAdjusted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should leave the bars of an adjusted source unchanged through a split", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := newTestRepository(Options{
+			Source:        filepath.Join("testdata", "{ticker_lower}.us.csv"),
+			SplitAdjusted: true,
+		}, 1)
+		bars, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+		require.NoError(t, err)
+
+		etf := entities.NewETF("SPY")
+		etf.SetPriceBars(bars)
+
+		// when
+		etf.AdjustForSplits([]entities.Split{
+			{Date: time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), Numerator: 2, Denominator: 1},
+		})
+
+		// then
+		require.Len(t, etf.PriceBars, 3)
+		assert.True(t, etf.PriceBars[0].SplitAdjusted)
+		assert.InDelta(t, 472.65, etf.PriceBars[0].Close, 0.001)
+		assert.InDelta(t, 123623700, etf.PriceBars[0].Volume, 0.001)
+	})
+}
@@ -0,0 +1,204 @@
+package csvrates
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/csvprices"
+)
+
+const (
+	// basePlaceholder is replaced with the base currency of the pair in the source.
+	basePlaceholder = "{base}"
+
+	// quotePlaceholder is replaced with the quote currency of the pair in the source.
+	quotePlaceholder = "{quote}"
+
+	// byteOrderMark starts the files exported by some spreadsheets.
+	byteOrderMark = "\uFEFF"
+
+	// bodyExcerptLength is the number of bytes of an error response body kept in the returned error.
+	bodyExcerptLength = 256
+)
+
+var (
+	// ErrMissingColumn is returned when the CSV header lacks the date or the rate column.
+	ErrMissingColumn = errors.New("missing column")
+
+	// ErrUnexpectedStatus is returned when the source URL answers with a status code that is not successful.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+)
+
+// Options describes where the CSV exchange rates live and how to read them. Zero values fall back to
+// comma-separated rows with ISO dates under the Date and Rate headers.
+type Options struct {
+	Source       string // URL template or local path template, with the {base} and {quote} placeholders.
+	DateFormat   string // Go layout of the dates, such as "2006-01-02" or "02/01/2006".
+	Delimiter    rune
+	DecimalComma bool // Whether the rates use a comma as the decimal separator.
+	DateColumn   string
+	RateColumn   string
+	HTTPClient   *http.Client
+}
+
+type CSVRatesRepository struct {
+	options Options
+}
+
+func NewCSVRatesRepository(options Options) *CSVRatesRepository {
+	if options.DateFormat == "" {
+		options.DateFormat = time.DateOnly
+	}
+
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+
+	if options.DateColumn == "" {
+		options.DateColumn = "Date"
+	}
+
+	if options.RateColumn == "" {
+		options.RateColumn = "Rate"
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+
+	return &CSVRatesRepository{options: options}
+}
+
+// IsLocal reports whether the source is a local file, which needs neither the network nor the cache.
+func (r *CSVRatesRepository) IsLocal() bool {
+	return !strings.HasPrefix(r.options.Source, "http://") && !strings.HasPrefix(r.options.Source, "https://")
+}
+
+func (r *CSVRatesRepository) ListRatesByPair(
+	ctx context.Context,
+	base, quote string,
+	from, to time.Time,
+) ([]entities.FXRate, error) {
+	source := strings.NewReplacer(basePlaceholder, base, quotePlaceholder, quote).Replace(r.options.Source)
+
+	reader, err := r.open(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	rows, err := r.parse(reader, source)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]entities.FXRate, 0, len(rows))
+
+	for _, row := range rows {
+		if row.Date.Before(from) || row.Date.After(to) {
+			continue
+		}
+
+		row.Base, row.Quote = base, quote
+		rates = append(rates, row)
+	}
+
+	return rates, nil
+}
+
+// open returns the content of the local file or of the URL.
+func (r *CSVRatesRepository) open(ctx context.Context, source string) (io.ReadCloser, error) {
+	if r.IsLocal() {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the CSV file: %w", err)
+		}
+
+		return file, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := r.options.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, bodyExcerptLength))
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %d from %s: %s", ErrUnexpectedStatus, resp.StatusCode, source, excerpt)
+	}
+
+	return resp.Body, nil
+}
+
+// parse reads every rate of the file, skipping the rows whose date or rate cannot be parsed.
+func (r *CSVRatesRepository) parse(reader io.Reader, source string) ([]entities.FXRate, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = r.options.Delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header of %s: %w", source, err)
+	}
+
+	dateIndex, rateIndex := -1, -1
+	for index, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, byteOrderMark))) {
+		case strings.ToLower(r.options.DateColumn):
+			dateIndex = index
+		case strings.ToLower(r.options.RateColumn):
+			rateIndex = index
+		}
+	}
+
+	if dateIndex == -1 || rateIndex == -1 {
+		return nil, fmt.Errorf(
+			"%w in %s: %s and %s are required", ErrMissingColumn, source, r.options.DateColumn, r.options.RateColumn,
+		)
+	}
+
+	var rates []entities.FXRate
+
+	for {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read the CSV rows of %s: %w", source, readErr)
+		}
+
+		if dateIndex >= len(record) || rateIndex >= len(record) {
+			continue
+		}
+
+		date, dateErr := time.Parse(r.options.DateFormat, strings.TrimSpace(record[dateIndex]))
+		rate, rateErr := csvprices.ParseNumber(record[rateIndex], r.options.DecimalComma)
+		if dateErr != nil || rateErr != nil {
+			continue
+		}
+
+		rates = append(rates, entities.FXRate{Date: date, Rate: rate})
+	}
+
+	return rates, nil
+}
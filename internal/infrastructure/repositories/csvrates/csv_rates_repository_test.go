@@ -0,0 +1,117 @@
+package csvrates
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVRatesRepository_ListRatesByPair(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should read the rates of the pair inside the period and skip the invalid rows", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVRatesRepository(Options{Source: filepath.Join("testdata", "{base}_{quote}.csv")})
+
+		// when
+		result, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, repository.IsLocal())
+		assert.Equal(t, []entities.FXRate{
+			{Date: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), Base: "USD", Quote: "BRL", Rate: 4.8916},
+			{Date: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC), Base: "USD", Quote: "BRL", Rate: 4.9206},
+		}, result)
+	})
+
+	t.Run("should map the columns, date format and decimal comma of a spreadsheet export", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVRatesRepository(Options{
+			Source:       filepath.Join("testdata", "ptax_export.csv"),
+			DateFormat:   "02/01/2006",
+			Delimiter:    ';',
+			DecimalComma: true,
+			DateColumn:   "Data",
+			RateColumn:   "Cotacao",
+		})
+
+		// when
+		result, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.InDelta(t, 4.8916, result[0].Rate, 0.00001)
+	})
+
+	t.Run("should strip the currency symbol and the thousands separators of the rates", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVRatesRepository(Options{
+			Source:       filepath.Join("testdata", "brl_export.csv"),
+			DateFormat:   "02/01/2006",
+			Delimiter:    ';',
+			DecimalComma: true,
+			DateColumn:   "Data",
+			RateColumn:   "Cotacao",
+		})
+
+		// when
+		result, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.InDelta(t, 4.8916, result[0].Rate, 0.00001)
+		assert.InDelta(t, 1234.5, result[1].Rate, 0.00001)
+	})
+
+	t.Run("should return an error when the rate column is missing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewCSVRatesRepository(Options{
+			Source:     filepath.Join("testdata", "{base}_{quote}.csv"),
+			RateColumn: "Close",
+		})
+
+		// when
+		_, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.ErrorIs(t, err, ErrMissingColumn)
+	})
+
+	t.Run("should return an error when the URL answers with an error status", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		repository := NewCSVRatesRepository(Options{Source: server.URL + "/{base}{quote}.csv"})
+
+		// when
+		_, err := repository.ListRatesByPair(context.Background(), "USD", "BRL", from, to)
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
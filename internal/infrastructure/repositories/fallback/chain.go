@@ -0,0 +1,91 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+// Provider pairs a repository with the name recorded as the source of the data it supplies.
+type Provider[R any] struct {
+	Name       string
+	Repository R
+}
+
+// chain describes the providers of a repository, in the order they are tried.
+type chain[T any] struct {
+	kind       string
+	names      []string
+	fetchers   []func(ctx context.Context, etf string) ([]T, error)
+	years      int
+	now        func() time.Time
+	yearOf     func(record T) int
+	withSource func(record T, source string) T
+}
+
+// fillYears asks the providers in order for the records of the ETF. The first provider with data supplies every
+// year it covers, and the following ones are only asked while some of the expected years are still missing,
+// contributing just the years no previous provider covered. Each record is tagged with the provider it came from.
+// The providers' errors are only returned when none of them had any data.
+func fillYears[T any](ctx context.Context, c chain[T], etf string) ([]T, error) {
+	missing := expectedYears(c.now(), c.years)
+	taken := make(map[int]bool)
+
+	var (
+		records []T
+		errs    []error
+	)
+
+	for index, fetch := range c.fetchers {
+		if len(missing) == 0 || ctx.Err() != nil {
+			break
+		}
+
+		fetched, err := fetch(ctx, etf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.names[index], err))
+
+			if index < len(c.fetchers)-1 {
+				logger.WithError(err).Warnf("Falling back from %s for the %s of: %s", c.names[index], c.kind, etf)
+			}
+
+			continue
+		}
+
+		// A year is never mixed across providers, so the years taken by the previous ones are skipped.
+		supplied := make(map[int]bool)
+		for _, record := range fetched {
+			year := c.yearOf(record)
+			if taken[year] {
+				continue
+			}
+
+			supplied[year] = true
+			records = append(records, c.withSource(record, c.names[index]))
+		}
+
+		for year := range supplied {
+			taken[year] = true
+			delete(missing, year)
+		}
+	}
+
+	if len(records) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return records, nil
+}
+
+// expectedYears returns the years the report covers, from the current year backwards.
+func expectedYears(now time.Time, years int) map[int]bool {
+	expected := make(map[int]bool, years)
+	for i := range years {
+		expected[now.Year()-i] = true
+	}
+
+	return expected
+}
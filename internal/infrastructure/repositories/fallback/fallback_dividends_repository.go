@@ -0,0 +1,46 @@
+package fallback
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// FallbackDividendsRepository tries the dividends providers in order, filling the years missing from the
+// previous ones and recording the source of each payment.
+type FallbackDividendsRepository struct {
+	chain chain[entities.Dividend]
+}
+
+func NewFallbackDividendsRepository(
+	providers []Provider[repositories.DividendsRepository],
+	years int,
+) *FallbackDividendsRepository {
+	repository := &FallbackDividendsRepository{
+		chain: chain[entities.Dividend]{
+			kind:  "dividends",
+			years: years,
+			now:   time.Now,
+			yearOf: func(dividend entities.Dividend) int {
+				return dividend.Date().Year()
+			},
+			withSource: func(dividend entities.Dividend, source string) entities.Dividend {
+				dividend.Source = source
+				return dividend
+			},
+		},
+	}
+
+	for _, provider := range providers {
+		repository.chain.names = append(repository.chain.names, provider.Name)
+		repository.chain.fetchers = append(repository.chain.fetchers, provider.Repository.ListDividendsByETF)
+	}
+
+	return repository
+}
+
+func (r *FallbackDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
+	return fillYears(ctx, r.chain, etf)
+}
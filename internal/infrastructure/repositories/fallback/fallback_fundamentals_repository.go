@@ -0,0 +1,46 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// FallbackFundamentalsRepository returns the fundamentals of the first provider that has any.
+type FallbackFundamentalsRepository struct {
+	providers []Provider[repositories.FundamentalsRepository]
+}
+
+func NewFallbackFundamentalsRepository(
+	providers []Provider[repositories.FundamentalsRepository],
+) *FallbackFundamentalsRepository {
+	return &FallbackFundamentalsRepository{providers: providers}
+}
+
+func (r *FallbackFundamentalsRepository) GetFundamentalsByETF(
+	ctx context.Context,
+	etf string,
+) (entities.Fundamentals, error) {
+	var errs []error
+
+	for _, provider := range r.providers {
+		if ctx.Err() != nil {
+			break
+		}
+
+		fundamentals, err := provider.Repository.GetFundamentalsByETF(ctx, etf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+			continue
+		}
+
+		if fundamentals != (entities.Fundamentals{}) {
+			return fundamentals, nil
+		}
+	}
+
+	return entities.Fundamentals{}, errors.Join(errs...)
+}
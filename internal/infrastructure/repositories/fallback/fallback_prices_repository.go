@@ -0,0 +1,46 @@
+package fallback
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// FallbackPricesRepository tries the prices providers in order, filling the years missing from the previous ones
+// and recording the source of each bar.
+type FallbackPricesRepository struct {
+	chain chain[entities.PriceBar]
+}
+
+func NewFallbackPricesRepository(
+	providers []Provider[repositories.PricesRepository],
+	years int,
+) *FallbackPricesRepository {
+	repository := &FallbackPricesRepository{
+		chain: chain[entities.PriceBar]{
+			kind:  "prices",
+			years: years,
+			now:   time.Now,
+			yearOf: func(bar entities.PriceBar) int {
+				return bar.Date.Year()
+			},
+			withSource: func(bar entities.PriceBar, source string) entities.PriceBar {
+				bar.Source = source
+				return bar
+			},
+		},
+	}
+
+	for _, provider := range providers {
+		repository.chain.names = append(repository.chain.names, provider.Name)
+		repository.chain.fetchers = append(repository.chain.fetchers, provider.Repository.ListPriceBarsByETF)
+	}
+
+	return repository
+}
+
+func (r *FallbackPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
+	return fillYears(ctx, r.chain, etf)
+}
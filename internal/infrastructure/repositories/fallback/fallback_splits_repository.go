@@ -0,0 +1,44 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+)
+
+// FallbackSplitsRepository returns the splits of the first provider that answers, recording it as their source.
+// Most funds never split, so an empty answer is as good as any other.
+type FallbackSplitsRepository struct {
+	providers []Provider[repositories.SplitsRepository]
+}
+
+func NewFallbackSplitsRepository(providers []Provider[repositories.SplitsRepository]) *FallbackSplitsRepository {
+	return &FallbackSplitsRepository{providers: providers}
+}
+
+func (r *FallbackSplitsRepository) ListSplitsByETF(ctx context.Context, etf string) ([]entities.Split, error) {
+	var errs []error
+
+	for _, provider := range r.providers {
+		if ctx.Err() != nil {
+			break
+		}
+
+		splits, err := provider.Repository.ListSplitsByETF(ctx, etf)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+			continue
+		}
+
+		for index := range splits {
+			splits[index].Source = provider.Name
+		}
+
+		return splits, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
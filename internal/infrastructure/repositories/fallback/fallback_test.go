@@ -0,0 +1,213 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("service unavailable")
+
+type stubDividendsRepository struct {
+	data  []entities.Dividend
+	err   error
+	calls int
+}
+
+func (s *stubDividendsRepository) ListDividendsByETF(_ context.Context, _ string) ([]entities.Dividend, error) {
+	s.calls++
+
+	return s.data, s.err
+}
+
+type stubFundamentalsRepository struct {
+	data entities.Fundamentals
+	err  error
+}
+
+func (s *stubFundamentalsRepository) GetFundamentalsByETF(_ context.Context, _ string) (entities.Fundamentals, error) {
+	return s.data, s.err
+}
+
+type stubSplitsRepository struct {
+	data []entities.Split
+	err  error
+}
+
+func (s *stubSplitsRepository) ListSplitsByETF(_ context.Context, _ string) ([]entities.Split, error) {
+	return s.data, s.err
+}
+
+func day(year int, month time.Month, date int) time.Time {
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestDividendsRepository(
+	years int,
+	stubs map[string]*stubDividendsRepository,
+	order ...string,
+) *FallbackDividendsRepository {
+	providers := make([]Provider[repositories.DividendsRepository], 0, len(order))
+	for _, name := range order {
+		providers = append(providers, Provider[repositories.DividendsRepository]{Name: name, Repository: stubs[name]})
+	}
+
+	repository := NewFallbackDividendsRepository(providers, years)
+	repository.chain.now = func() time.Time { return day(2025, time.June, 1) }
+
+	return repository
+}
+
+func TestFallback_FallbackDividendsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not ask the next providers when the first one covers every year", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		stubs := map[string]*stubDividendsRepository{
+			"nasdaq": {data: []entities.Dividend{
+				{PaymentDate: day(2025, time.March, 1), Amount: 1},
+				{PaymentDate: day(2024, time.March, 1), Amount: 1},
+			}},
+			"historyorg": {},
+		}
+		repository := newTestDividendsRepository(2, stubs, "nasdaq", "historyorg")
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "nasdaq", result[0].Source)
+		assert.Zero(t, stubs["historyorg"].calls)
+	})
+
+	t.Run("should fall back to the next provider when the first one fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		stubs := map[string]*stubDividendsRepository{
+			"nasdaq":     {err: errUnavailable},
+			"historyorg": {data: []entities.Dividend{{PaymentDate: day(2025, time.March, 1), Amount: 1}}},
+		}
+		repository := newTestDividendsRepository(1, stubs, "nasdaq", "historyorg")
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "historyorg", result[0].Source)
+	})
+
+	t.Run("should only take the missing years from the next provider", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		stubs := map[string]*stubDividendsRepository{
+			"nasdaq": {data: []entities.Dividend{{PaymentDate: day(2025, time.March, 1), Amount: 1}}},
+			"historyorg": {data: []entities.Dividend{
+				{PaymentDate: day(2025, time.March, 1), Amount: 2},
+				{PaymentDate: day(2024, time.March, 1), Amount: 3},
+			}},
+		}
+		repository := newTestDividendsRepository(2, stubs, "nasdaq", "historyorg")
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Dividend{
+			{PaymentDate: day(2025, time.March, 1), Amount: 1, Source: "nasdaq"},
+			{PaymentDate: day(2024, time.March, 1), Amount: 3, Source: "historyorg"},
+		}, result)
+	})
+
+	t.Run("should return every provider error when none of them has data", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		stubs := map[string]*stubDividendsRepository{
+			"nasdaq":     {err: errUnavailable},
+			"historyorg": {},
+		}
+		repository := newTestDividendsRepository(1, stubs, "nasdaq", "historyorg")
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.ErrorIs(t, err, errUnavailable)
+		assert.Contains(t, err.Error(), "nasdaq")
+		assert.Empty(t, result)
+	})
+}
+
+func TestFallback_FallbackFundamentalsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should skip the providers that fail or have no fundamentals", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewFallbackFundamentalsRepository([]Provider[repositories.FundamentalsRepository]{
+			{Name: "nasdaq", Repository: &stubFundamentalsRepository{err: errUnavailable}},
+			{Name: "empty", Repository: &stubFundamentalsRepository{}},
+			{Name: "other", Repository: &stubFundamentalsRepository{data: entities.Fundamentals{Beta: 1}}},
+		})
+
+		// when
+		result, err := repository.GetFundamentalsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, result.Beta, 0.001)
+	})
+}
+
+func TestFallback_FallbackSplitsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should take the splits of the first provider that answers and record it as their source", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewFallbackSplitsRepository([]Provider[repositories.SplitsRepository]{
+			{Name: "nasdaq", Repository: &stubSplitsRepository{err: errUnavailable}},
+			{Name: "yahoo", Repository: &stubSplitsRepository{data: []entities.Split{{Numerator: 1, Denominator: 4}}}},
+		})
+
+		// when
+		result, err := repository.ListSplitsByETF(context.Background(), "SVOL")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "yahoo", result[0].Source)
+	})
+
+	t.Run("should return every provider error when none of them answers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewFallbackSplitsRepository([]Provider[repositories.SplitsRepository]{
+			{Name: "yahoo", Repository: &stubSplitsRepository{err: errUnavailable}},
+		})
+
+		// when
+		_, err := repository.ListSplitsByETF(context.Background(), "SVOL")
+
+		// then
+		require.ErrorIs(t, err, errUnavailable)
+	})
+}
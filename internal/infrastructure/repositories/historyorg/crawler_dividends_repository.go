@@ -1,11 +1,15 @@
 package historyorg
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gocolly/colly"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/scraping"
 )
 
 type CrawlerDividendsRepository struct {
@@ -15,21 +19,24 @@ func NewCrawlerDividendsRepository() *CrawlerDividendsRepository {
 	return &CrawlerDividendsRepository{}
 }
 
-func (r CrawlerDividendsRepository) ListDividendsByETF(etf string) (map[string]float64, error) {
-	c := colly.NewCollector()
+func (r CrawlerDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
+	c := scraping.NewCollector(ctx)
 
-	yearlyTotals := make(map[string]float64)
+	var dividends []entities.Dividend
 
 	c.OnHTML("table#dividend_table tbody tr", func(e *colly.HTMLElement) {
-		date := e.ChildText("td:nth-child(2)")        // Payout Date
-		dividendStr := e.ChildText("td:nth-child(3)") // Cash Amount
-
-		year, _, _ := strings.Cut(date, "-")
+		exDate, _ := time.Parse(time.DateOnly, e.ChildText("td:nth-child(1)"))      // Ex-Dividend Date
+		paymentDate, _ := time.Parse(time.DateOnly, e.ChildText("td:nth-child(2)")) // Payout Date
+		dividendStr := e.ChildText("td:nth-child(3)")                               // Cash Amount
 
 		dividendStr = strings.TrimSpace(strings.ReplaceAll(dividendStr, "$", ""))
 		dividend, err := strconv.ParseFloat(dividendStr, 64)
-		if err == nil && year != "" {
-			yearlyTotals[year] += dividend
+		if err == nil && (!exDate.IsZero() || !paymentDate.IsZero()) {
+			dividends = append(dividends, entities.Dividend{
+				ExDate:      exDate,
+				PaymentDate: paymentDate,
+				Amount:      dividend,
+			})
 		}
 	})
 
@@ -38,5 +45,5 @@ func (r CrawlerDividendsRepository) ListDividendsByETF(etf string) (map[string]f
 		return nil, fmt.Errorf("failed to visit URL: %w", err)
 	}
 
-	return yearlyTotals, nil
+	return dividends, nil
 }
@@ -2,67 +2,55 @@ package nasdaq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
 )
 
 type APIDividendsRepository struct {
+	client *Client
 }
 
-func NewAPIDividendsRepository() *APIDividendsRepository {
-	return &APIDividendsRepository{}
+func NewAPIDividendsRepository(client *Client) *APIDividendsRepository {
+	return &APIDividendsRepository{client: client}
 }
 
-func (r *APIDividendsRepository) ListDividendsByETF(etf string) (map[string]float64, error) {
-	url := fmt.Sprintf("https://api.nasdaq.com/api/quote/%s/dividends?assetclass=etf", etf)
-
-	ctx := context.Background()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set(
-		"User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 "+
-			"(KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0",
-	)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
+func (r *APIDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
 	var result struct {
 		Data struct {
 			Dividends struct {
 				Rows []struct {
-					Amount      string `json:"amount"`
-					PaymentDate string `json:"paymentDate"`
+					ExOrEffDate     string `json:"exOrEffDate"`
+					Amount          string `json:"amount"`
+					DeclarationDate string `json:"declarationDate"`
+					RecordDate      string `json:"recordDate"`
+					PaymentDate     string `json:"paymentDate"`
 				} `json:"rows"`
 			} `json:"dividends"`
 		} `json:"data"`
 	}
 
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf("/api/quote/%s/dividends?assetclass=etf", etf)
+	if err := r.client.GetJSON(ctx, path, &result); err != nil {
+		return nil, err
 	}
 
-	yearlySums := make(map[string]float64)
+	dividends := make([]entities.Dividend, 0, len(result.Data.Dividends.Rows))
 
 	for _, row := range result.Data.Dividends.Rows {
 		amount, parseErr := strconv.ParseFloat(strings.ReplaceAll(row.Amount, "$", ""), 64)
 		if parseErr == nil {
-			year := strings.Split(row.PaymentDate, "/")[2]
-			yearlySums[year] += amount
+			dividends = append(dividends, entities.Dividend{
+				ExDate:          parseDate(row.ExOrEffDate),
+				RecordDate:      parseDate(row.RecordDate),
+				DeclarationDate: parseDate(row.DeclarationDate),
+				PaymentDate:     parseDate(row.PaymentDate),
+				Amount:          amount,
+			})
 		}
 	}
 
-	return yearlySums, nil
+	return dividends, nil
 }
@@ -0,0 +1,55 @@
+package nasdaq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNasdaq_APIDividendsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse every date of the payments and leave the N/A ones empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIDividendsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Dividend{
+			{
+				ExDate:          time.Date(2025, time.March, 21, 0, 0, 0, 0, time.UTC),
+				RecordDate:      time.Date(2025, time.March, 21, 0, 0, 0, 0, time.UTC),
+				DeclarationDate: time.Date(2025, time.March, 19, 0, 0, 0, 0, time.UTC),
+				PaymentDate:     time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC),
+				Amount:          1.69553,
+			},
+			{
+				ExDate:      time.Date(2024, time.December, 20, 0, 0, 0, 0, time.UTC),
+				PaymentDate: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+				Amount:      1.96555,
+			},
+		}, result)
+	})
+
+	t.Run("should fail when the ticker is not found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIDividendsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		_, err := repository.ListDividendsByETF(context.Background(), "NONE")
+
+		// then
+		require.Error(t, err)
+	})
+}
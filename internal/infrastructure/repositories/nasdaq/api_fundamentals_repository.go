@@ -0,0 +1,129 @@
+package nasdaq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+// errFundamentalsNotFound is returned when neither endpoint contains any of the expected fields.
+var errFundamentalsNotFound = errors.New("no fundamentals found")
+
+type nasdaqField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+type APIFundamentalsRepository struct {
+	client *Client
+}
+
+func NewAPIFundamentalsRepository(client *Client) *APIFundamentalsRepository {
+	return &APIFundamentalsRepository{client: client}
+}
+
+func (r *APIFundamentalsRepository) GetFundamentalsByETF(ctx context.Context, etf string) (entities.Fundamentals, error) {
+	var summary struct {
+		Data struct {
+			SummaryData map[string]nasdaqField `json:"summaryData"`
+		} `json:"data"`
+	}
+
+	summaryPath := fmt.Sprintf("/api/quote/%s/summary?assetclass=etf", etf)
+	if err := r.client.GetJSON(ctx, summaryPath, &summary); err != nil {
+		return entities.Fundamentals{}, err
+	}
+
+	var info struct {
+		Data struct {
+			KeyStats map[string]nasdaqField `json:"keyStats"`
+		} `json:"data"`
+	}
+
+	infoPath := fmt.Sprintf("/api/quote/%s/info?assetclass=etf", etf)
+	if err := r.client.GetJSON(ctx, infoPath, &info); err != nil {
+		return entities.Fundamentals{}, err
+	}
+
+	fields := make(map[string]string)
+	for key, field := range info.Data.KeyStats {
+		fields[strings.ToLower(key)] = field.Value
+	}
+	for key, field := range summary.Data.SummaryData {
+		fields[strings.ToLower(key)] = field.Value
+	}
+
+	fundamentals := entities.Fundamentals{
+		PayoutFrequency: lookup(fields, "dividendfrequency", "payoutfrequency"),
+		AverageVolume:   parseFigure(lookup(fields, "averagevolume", "fiftydayavgdailyvol", "avgdailyvol")),
+		ExpenseRatio:    parseFigure(lookup(fields, "expenseratio", "netexpenseratio")),
+		Beta:            parseFigure(lookup(fields, "beta")),
+		AUM:             parseFigure(lookup(fields, "aum", "totalnetassets", "netassets", "marketcap")),
+		InceptionDate:   parseDate(lookup(fields, "inceptiondate", "fundinceptiondate")),
+	}
+
+	if fundamentals == (entities.Fundamentals{}) {
+		return fundamentals, fmt.Errorf("%w for ETF: %s", errFundamentalsNotFound, etf)
+	}
+
+	return fundamentals, nil
+}
+
+// lookup returns the first non-empty value among the candidate keys, ignoring Nasdaq's "N/A" placeholders.
+func lookup(fields map[string]string, keys ...string) string {
+	for _, key := range keys {
+		value := strings.TrimSpace(fields[key])
+		if value != "" && !strings.EqualFold(value, "N/A") {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// parseFigure converts values such as "$1.23B", "0.09%" or "78,123,456" into a float.
+func parseFigure(value string) float64 {
+	value = strings.NewReplacer("$", "", ",", "", "%", "").Replace(strings.TrimSpace(value))
+	if value == "" {
+		return 0
+	}
+
+	multiplier := 1.0
+	switch strings.ToUpper(value[len(value)-1:]) {
+	case "K":
+		multiplier = 1e3
+	case "M":
+		multiplier = 1e6
+	case "B":
+		multiplier = 1e9
+	case "T":
+		multiplier = 1e12
+	}
+
+	if multiplier != 1.0 {
+		value = value[:len(value)-1]
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+
+	return number * multiplier
+}
+
+// parseDate converts a date in any of the Nasdaq layouts, returning the zero time when none matches.
+func parseDate(value string) time.Time {
+	for _, layout := range []string{"01/02/2006", "Jan 2, 2006", time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,139 @@
+package nasdaq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNasdaq_APIFundamentalsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should merge the summary and the key stats of a normal response", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIFundamentalsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		fundamentals, err := repository.GetFundamentalsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, entities.Fundamentals{
+			PayoutFrequency: "Quarterly",
+			AverageVolume:   78_123_456,
+			ExpenseRatio:    0.09,
+			Beta:            1,
+			AUM:             560.12e9,
+			InceptionDate:   time.Date(1993, time.January, 22, 0, 0, 0, 0, time.UTC),
+		}, fundamentals)
+	})
+
+	t.Run("should leave the missing and N/A fields empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIFundamentalsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		fundamentals, err := repository.GetFundamentalsByETF(context.Background(), "JEPI")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, entities.Fundamentals{ExpenseRatio: 0.35}, fundamentals)
+	})
+
+	t.Run("should fail when none of the values can be parsed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIFundamentalsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		_, err := repository.GetFundamentalsByETF(context.Background(), "BAD")
+
+		// then
+		require.ErrorIs(t, err, errFundamentalsNotFound)
+		assert.ErrorContains(t, err, "BAD")
+	})
+
+	t.Run("should fail when the ticker is not found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIFundamentalsRepository(newTestClient(newFixtureServer(t)))
+
+		// when
+		_, err := repository.GetFundamentalsByETF(context.Background(), "NONE")
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestNasdaq_ParseFigure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected float64
+	}{
+		{value: "$1.23B", expected: 1.23e9},
+		{value: "0.09%", expected: 0.09},
+		{value: "78,123,456", expected: 78_123_456},
+		{value: "12.5k", expected: 12_500},
+		{value: "$2T", expected: 2e12},
+		{value: "", expected: 0},
+		{value: "$B", expected: 0},
+		{value: "high", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run("should parse "+test.value, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			// the value of the test
+
+			// when
+			result := parseFigure(test.value)
+
+			// then
+			assert.InDelta(t, test.expected, result, 0.001)
+		})
+	}
+}
+
+func TestNasdaq_ParseDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "01/22/1993", expected: time.Date(1993, time.January, 22, 0, 0, 0, 0, time.UTC)},
+		{value: "May 20, 2020", expected: time.Date(2020, time.May, 20, 0, 0, 0, 0, time.UTC)},
+		{value: "2020-05-20", expected: time.Date(2020, time.May, 20, 0, 0, 0, 0, time.UTC)},
+		{value: "sometime in 2020", expected: time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run("should parse "+test.value, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			// the value of the test
+
+			// when
+			result := parseDate(test.value)
+
+			// then
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
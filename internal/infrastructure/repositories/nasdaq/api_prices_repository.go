@@ -2,91 +2,72 @@ package nasdaq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
 )
 
 const (
-	// YearsToFetch is the number of years to fetch data for.
-	YearsToFetch = 5
-
 	// NumberOfDaysInYear is the approximate number of trading data points in a year.
 	NumberOfDaysInYear = 365
 )
 
 type APIPricesRepository struct {
+	client       *Client
+	yearsToFetch int
 }
 
-func NewAPIPricesRepository() *APIPricesRepository {
-	return &APIPricesRepository{}
+func NewAPIPricesRepository(client *Client, yearsToFetch int) *APIPricesRepository {
+	return &APIPricesRepository{client: client, yearsToFetch: yearsToFetch}
 }
 
-func (r APIPricesRepository) ListClosingPricesByETF(etf string) (map[string]float64, error) {
-	averageClosePrices := make(map[string]float64)
+func (r APIPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
 	currentYear := time.Now().Year()
-	fromDate := fmt.Sprintf("%d-01-01", currentYear-YearsToFetch)
+	fromDate := fmt.Sprintf("%d-01-01", currentYear-r.yearsToFetch)
 	toDate := fmt.Sprintf("%d-12-31", currentYear)
 
-	url := fmt.Sprintf(
-		"https://api.nasdaq.com/api/quote/%s/historical?assetclass=etf&fromdate=%s&todate=%s&limit=%d&offset=0",
-		etf, fromDate, toDate, YearsToFetch*NumberOfDaysInYear,
-	)
-	ctx := context.Background()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set(
-		"User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 "+
-			"(KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0",
-	)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
 	var result struct {
 		Data struct {
 			TradesTable struct {
 				Rows []struct {
-					Close string `json:"close"`
-					Date  string `json:"date"`
+					Date   string `json:"date"`
+					Close  string `json:"close"`
+					Volume string `json:"volume"`
+					Open   string `json:"open"`
+					High   string `json:"high"`
+					Low    string `json:"low"`
 				} `json:"rows"`
 			} `json:"tradesTable"`
 		} `json:"data"`
 	}
 
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf(
+		"/api/quote/%s/historical?assetclass=etf&fromdate=%s&todate=%s&limit=%d&offset=0",
+		etf, fromDate, toDate, r.yearsToFetch*NumberOfDaysInYear,
+	)
+	if err := r.client.GetJSON(ctx, path, &result); err != nil {
+		return nil, err
 	}
 
-	yearlySums := make(map[string]float64)
-	yearlyCounts := make(map[string]int)
+	bars := make([]entities.PriceBar, 0, len(result.Data.TradesTable.Rows))
 
 	for _, row := range result.Data.TradesTable.Rows {
+		date := parseDate(row.Date)
 		closePrice, parseErr := strconv.ParseFloat(strings.ReplaceAll(row.Close, "$", ""), 64)
-		if parseErr == nil {
-			year := strings.Split(row.Date, "/")[2]
-			yearlySums[year] += closePrice
-			yearlyCounts[year]++
-		}
-	}
-
-	for year, sum := range yearlySums {
-		if count, exists := yearlyCounts[year]; exists && count > 0 {
-			averageClosePrices[year] = sum / float64(count)
+		if parseErr == nil && !date.IsZero() {
+			bars = append(bars, entities.PriceBar{
+				Date:   date,
+				Open:   parseFigure(row.Open),
+				High:   parseFigure(row.High),
+				Low:    parseFigure(row.Low),
+				Close:  closePrice,
+				Volume: parseFigure(row.Volume),
+			})
 		}
 	}
 
-	return averageClosePrices, nil
+	return bars, nil
 }
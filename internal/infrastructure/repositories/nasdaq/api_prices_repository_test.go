@@ -0,0 +1,60 @@
+package nasdaq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNasdaq_APIPricesRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse the daily bars and skip the ones without a close or a date", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIPricesRepository(newTestClient(newFixtureServer(t)), 1)
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.PriceBar{
+			{
+				Date:   time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC),
+				Open:   596.27,
+				High:   599.70,
+				Low:    593.60,
+				Close:  595.36,
+				Volume: 47_679_440,
+			},
+			{
+				Date:   time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC),
+				Open:   587.53,
+				High:   592.60,
+				Low:    585.42,
+				Close:  591.95,
+				Volume: 37_888_470,
+			},
+			{Date: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Close: 586.08},
+		}, result)
+	})
+
+	t.Run("should fail when the ticker is not found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIPricesRepository(newTestClient(newFixtureServer(t)), 1)
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "NONE")
+
+		// then
+		require.Error(t, err)
+	})
+}
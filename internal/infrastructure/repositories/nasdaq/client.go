@@ -0,0 +1,227 @@
+package nasdaq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the address of the Nasdaq REST API.
+	DefaultBaseURL = "https://api.nasdaq.com"
+
+	// DefaultRequestsPerSecond is the sustained request rate shared by every repository using the client.
+	DefaultRequestsPerSecond = 4
+
+	// DefaultBurst is the number of requests that can be sent at once before the rate limit applies.
+	DefaultBurst = 4
+
+	// DefaultMaxRetries is the number of retries after the first attempt of a retryable failure.
+	DefaultMaxRetries = 4
+
+	// DefaultBaseDelay is the delay before the first retry, doubled on each following retry.
+	DefaultBaseDelay = 500 * time.Millisecond
+
+	// DefaultMaxDelay caps the delay between retries, including the delays requested with Retry-After.
+	DefaultMaxDelay = 30 * time.Second
+
+	// userAgent is a browser-like user agent, since Nasdaq blocks requests without one.
+	userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 " +
+		"(KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0"
+
+	// bodyExcerptLength is the number of bytes of an error response body kept in the returned error.
+	bodyExcerptLength = 256
+)
+
+// ErrUnexpectedStatus is returned when Nasdaq answers with a status code that is not successful.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// ClientOptions holds the settings of the client. Zero values fall back to the defaults.
+type ClientOptions struct {
+	BaseURL           string
+	HTTPClient        *http.Client
+	RequestsPerSecond float64
+	Burst             int
+	MaxRetries        int
+	BaseDelay         time.Duration
+	MaxDelay          time.Duration
+}
+
+// Client is the HTTP client shared by the Nasdaq repositories. It rate limits every request with a token bucket
+// and retries the rate-limited and server-side failures with exponential backoff and jitter.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	limiter    *rateLimiter
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func NewClient(options ClientOptions) *Client {
+	client := &Client{
+		baseURL:    options.BaseURL,
+		httpClient: options.HTTPClient,
+		maxRetries: options.MaxRetries,
+		baseDelay:  options.BaseDelay,
+		maxDelay:   options.MaxDelay,
+	}
+
+	if client.baseURL == "" {
+		client.baseURL = DefaultBaseURL
+	}
+
+	if client.httpClient == nil {
+		client.httpClient = http.DefaultClient
+	}
+
+	if client.maxRetries == 0 {
+		client.maxRetries = DefaultMaxRetries
+	}
+
+	if client.baseDelay == 0 {
+		client.baseDelay = DefaultBaseDelay
+	}
+
+	if client.maxDelay == 0 {
+		client.maxDelay = DefaultMaxDelay
+	}
+
+	requestsPerSecond, burst := options.RequestsPerSecond, options.Burst
+	if requestsPerSecond == 0 {
+		requestsPerSecond = DefaultRequestsPerSecond
+	}
+
+	if burst == 0 {
+		burst = DefaultBurst
+	}
+
+	client.limiter = newRateLimiter(requestsPerSecond, burst)
+
+	return client
+}
+
+// GetJSON requests the path, relative to the base URL, and decodes the JSON body into the target.
+func (c *Client) GetJSON(ctx context.Context, path string, target any) error {
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.attempt(ctx, path, target)
+		if err == nil {
+			return nil
+		}
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || attempt >= c.maxRetries {
+			return err
+		}
+
+		delay := c.backoff(attempt)
+		if retryAfter > 0 {
+			delay = min(retryAfter, c.maxDelay)
+		}
+
+		if err = sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// attempt sends a single request, returning the delay requested by the server when the failure can be retried.
+func (c *Client) attempt(ctx context.Context, path string, target any) (time.Duration, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The transport failures are retried, unless the context itself is done.
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("failed to fetch data: %w", err)
+		}
+
+		return 0, &retryableError{err: fmt.Errorf("failed to fetch data: %w", err)}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, bodyExcerptLength))
+		err = fmt.Errorf("%w: %d from %s: %s", ErrUnexpectedStatus, resp.StatusCode, path, excerpt)
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), &retryableError{err: err}
+		}
+
+		return 0, err
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return 0, nil
+}
+
+// backoff returns the exponential delay of the attempt with full jitter, capped by the maximum delay.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := min(c.baseDelay<<attempt, c.maxDelay)
+
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
+
+// retryableError marks the failures that are worth another attempt.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// parseRetryAfter converts the Retry-After header, in seconds or as an HTTP date, into a delay.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// sleep waits for the delay, returning early when the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
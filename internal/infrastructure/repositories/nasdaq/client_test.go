@@ -0,0 +1,198 @@
+package nasdaq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(server *httptest.Server) *Client {
+	return NewClient(ClientOptions{
+		BaseURL:           server.URL,
+		HTTPClient:        server.Client(),
+		RequestsPerSecond: 1000,
+		Burst:             10,
+		MaxRetries:        2,
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+	})
+}
+
+// newFixtureServer serves the files of the testdata directory named after the path, e.g. quote_spy_summary.json for
+// /api/quote/SPY/summary.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		name := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(req.URL.Path, "/api/"), "/", "_"))
+
+		content, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+		if err != nil {
+			http.NotFound(writer, req)
+			return
+		}
+
+		_, _ = writer.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_GetJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should retry a rate-limited request honoring Retry-After", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				writer.Header().Set("Retry-After", "0")
+				writer.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = writer.Write([]byte(`{"data":{"value":42}}`))
+		}))
+		defer server.Close()
+
+		var result struct {
+			Data struct {
+				Value int `json:"value"`
+			} `json:"data"`
+		}
+
+		// when
+		err := newTestClient(server).GetJSON(context.Background(), "/api/quote/SPY", &result)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 42, result.Data.Value)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should give up after the maximum retries of a server error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		// when
+		err := newTestClient(server).GetJSON(context.Background(), "/api/quote/SPY", &struct{}{})
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("should not retry a client error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			writer.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		// when
+		err := newTestClient(server).GetJSON(context.Background(), "/api/quote/NOPE", &struct{}{})
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+		assert.Contains(t, err.Error(), "404")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestClient_ParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should parse a delay in seconds", func(t *testing.T) {
+		t.Parallel()
+
+		// given, when
+		result := parseRetryAfter("7", now)
+
+		// then
+		assert.Equal(t, 7*time.Second, result)
+	})
+
+	t.Run("should parse an HTTP date", func(t *testing.T) {
+		t.Parallel()
+
+		// given, when
+		result := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+
+		// then
+		assert.Equal(t, time.Minute, result)
+	})
+
+	t.Run("should ignore an invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		// given, when
+		result := parseRetryAfter("soon", now)
+
+		// then
+		assert.Zero(t, result)
+	})
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow the burst and then wait for the bucket to refill", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newRateLimiter(2, 2)
+		limiter.last = now
+		limiter.now = func() time.Time { return now }
+
+		// when
+		first, second, third := limiter.reserve(), limiter.reserve(), limiter.reserve()
+
+		// then
+		assert.Zero(t, first)
+		assert.Zero(t, second)
+		assert.Equal(t, 500*time.Millisecond, third)
+	})
+
+	t.Run("should refill the tokens as time passes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+		limiter := newRateLimiter(2, 1)
+		limiter.last = now
+		limiter.now = func() time.Time { return now }
+		limiter.reserve()
+
+		// when
+		now = now.Add(500 * time.Millisecond)
+		result := limiter.reserve()
+
+		// then
+		assert.Zero(t, result)
+	})
+}
@@ -0,0 +1,26 @@
+package scraping
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gocolly/colly"
+)
+
+// contextTransport attaches the context to every request, so canceling it aborts the in-flight requests.
+type contextTransport struct {
+	ctx  context.Context //nolint:containedctx // colly v1 has no other way to receive the context
+	next http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx))
+}
+
+// NewCollector creates a collector whose requests are canceled together with the context.
+func NewCollector(ctx context.Context) *colly.Collector {
+	c := colly.NewCollector()
+	c.WithTransport(contextTransport{ctx: ctx, next: http.DefaultTransport})
+
+	return c
+}
@@ -0,0 +1,142 @@
+package statusinvest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// priceDateLayout is the format of the dates of the price history endpoint.
+	priceDateLayout = "02/01/06 15:04"
+
+	// priceHistoryRange selects the five-year daily history of the price endpoint, the longest it serves.
+	priceHistoryRange = "4"
+
+	// priceHistoryYears is the number of years the price history range covers.
+	priceHistoryYears = 5
+
+	// currencyTypeReal selects the prices in Brazilian reais, the currency of the B3 funds.
+	currencyTypeReal = 1
+
+	// currencyTypeDollar selects the prices in US dollars, the currency of the US ETFs.
+	currencyTypeDollar = 2
+
+	// userAgent is a browser-like user agent, since StatusInvest rejects requests without one.
+	userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 " +
+		"(KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0"
+)
+
+var (
+	// ErrUnexpectedStatus is returned when StatusInvest answers with a status code that is not successful.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+
+	// ErrUnexpectedCurrency is returned when StatusInvest does not serve the prices in the currency of the ticker.
+	ErrUnexpectedCurrency = errors.New("unexpected currency")
+)
+
+// priceSeries is the price history of a ticker in one of the currencies the endpoint serves.
+type priceSeries struct {
+	CurrencyType int    `json:"currencyType"`
+	Currency     string `json:"currency"`
+	Prices       []struct {
+		Price float64 `json:"price"`
+		Date  string  `json:"date"`
+	} `json:"prices"`
+}
+
+type APIPricesRepository struct {
+	baseURL      string
+	yearsToFetch int
+	now          func() time.Time
+}
+
+func NewAPIPricesRepository(yearsToFetch int) *APIPricesRepository {
+	return &APIPricesRepository{baseURL: DefaultBaseURL, yearsToFetch: yearsToFetch, now: time.Now}
+}
+
+func (r *APIPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
+	// The prices are requested in the currency the ticker is labeled and converted with.
+	currencyType := currencyTypeDollar
+	if entities.MarketOf(strings.ToUpper(etf)) == entities.MarketB3 {
+		currencyType = currencyTypeReal
+	}
+
+	if r.yearsToFetch > priceHistoryYears {
+		logger.Warnf(
+			"Status Invest only serves the last %d years of prices, the earlier years are missing for: %s",
+			priceHistoryYears, etf,
+		)
+	}
+
+	query := url.Values{
+		"ticker":      {strings.ToUpper(etf)},
+		"type":        {priceHistoryRange},
+		"currences[]": {strconv.Itoa(currencyType)},
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, r.baseURL+"/category/tickerprice?"+query.Encode(), nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("%w: %d for %s", ErrUnexpectedStatus, resp.StatusCode, etf)
+	}
+
+	var result []priceSeries
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	index := slices.IndexFunc(result, func(series priceSeries) bool { return series.CurrencyType == currencyType })
+	if index == -1 {
+		return nil, fmt.Errorf("%w for %s: %s", ErrUnexpectedCurrency, etf, result[0].Currency)
+	}
+
+	firstYear := r.now().Year() - r.yearsToFetch
+	bars := make([]entities.PriceBar, 0, len(result[index].Prices))
+
+	// The endpoint only serves the closing prices.
+	for _, price := range result[index].Prices {
+		moment, parseErr := time.Parse(priceDateLayout, price.Date)
+		if parseErr != nil || moment.Year() < firstYear || price.Price == 0 {
+			continue
+		}
+
+		bars = append(bars, entities.PriceBar{
+			Date:  time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC),
+			Close: price.Price,
+		})
+	}
+
+	return bars, nil
+}
@@ -1,52 +1,112 @@
 package statusinvest
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gocolly/colly"
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+	"github.com/rios0rios0/investmate/internal/infrastructure/repositories/scraping"
 	logger "github.com/sirupsen/logrus"
 )
 
+const (
+	// DefaultBaseURL is the address of StatusInvest.
+	DefaultBaseURL = "https://statusinvest.com.br"
+
+	// dateLayout is the Brazilian date format used by StatusInvest.
+	dateLayout = "02/01/2006"
+
+	// usETFsPath is the category of the pages of the ETFs listed in the United States.
+	usETFsPath = "/etf/eua/"
+
+	// realEstateFundsPath is the category of the pages of the B3 real-estate funds (FIIs).
+	realEstateFundsPath = "/fundos-imobiliarios/"
+
+	// b3ETFsPath is the category of the pages of the ETFs listed on B3.
+	b3ETFsPath = "/etfs/"
+)
+
 type CrawlerDividendsRepository struct {
+	baseURL string
 }
 
 func NewCrawlerDividendsRepository() *CrawlerDividendsRepository {
-	return &CrawlerDividendsRepository{}
+	return &CrawlerDividendsRepository{baseURL: DefaultBaseURL}
 }
 
-func (r CrawlerDividendsRepository) ListDividendsByETF(etf string) (map[string]float64, error) {
-	c := colly.NewCollector()
+func (r CrawlerDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
+	var errs []error
+
+	// The B3 tickers do not tell a real-estate fund from an ETF, so both categories are tried.
+	for _, path := range categoryPaths(etf) {
+		dividends, found, err := r.crawl(ctx, r.baseURL+path+strings.ToLower(etf))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 
-	yearlyTotals := make(map[string]float64)
+		if found {
+			return dividends, nil
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// crawl reads the payments listed in the earnings section of the page, reporting whether the page has one.
+func (r CrawlerDividendsRepository) crawl(ctx context.Context, url string) ([]entities.Dividend, bool, error) {
+	c := scraping.NewCollector(ctx)
+
+	var (
+		dividends []entities.Dividend
+		found     bool
+	)
 
 	c.OnHTML("div#earning-section input#results", func(e *colly.HTMLElement) {
+		found = true
 		jsonData := e.Attr("value")
 
-		var dividends []struct {
+		var results []struct {
 			Value       float64 `json:"v"`
+			ExDate      string  `json:"ed"`
 			PaymentDate string  `json:"pd"`
 		}
 
-		if err := json.Unmarshal([]byte(jsonData), &dividends); err != nil {
+		if err := json.Unmarshal([]byte(jsonData), &results); err != nil {
 			logger.Errorf("Failed to unmarshal JSON data: %v", err)
 			return
 		}
 
-		for _, dividend := range dividends {
-			year := dividend.PaymentDate
-			if year != "-" {
-				year = strings.Split(dividend.PaymentDate, "/")[2]
-			}
-			yearlyTotals[year] += dividend.Value
+		for _, result := range results {
+			// Payments not scheduled yet are reported with "-" as the date, which leaves the zero time.
+			exDate, _ := time.Parse(dateLayout, result.ExDate)
+			paymentDate, _ := time.Parse(dateLayout, result.PaymentDate)
+
+			dividends = append(dividends, entities.Dividend{
+				ExDate:      exDate,
+				PaymentDate: paymentDate,
+				Amount:      result.Value,
+			})
 		}
 	})
 
-	url := fmt.Sprintf("https://statusinvest.com.br/etf/eua/%s", etf)
 	if err := c.Visit(url); err != nil {
-		return nil, fmt.Errorf("failed to visit URL: %w", err)
+		return nil, false, fmt.Errorf("failed to visit URL %s: %w", url, err)
+	}
+
+	return dividends, found, nil
+}
+
+// categoryPaths returns the categories whose pages may list the ticker, in the order they are tried.
+func categoryPaths(etf string) []string {
+	if entities.MarketOf(strings.ToUpper(etf)) == entities.MarketB3 {
+		return []string{realEstateFundsPath, b3ETFsPath}
 	}
 
-	return yearlyTotals, nil
+	return []string{usETFsPath}
 }
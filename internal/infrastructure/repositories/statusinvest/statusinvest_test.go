@@ -0,0 +1,157 @@
+package statusinvest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureServer serves the recorded fixture named after the request path, and 404 for any other page.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		name := strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "_")
+		if name == "category_tickerprice" {
+			name = "tickerprice_" + strings.ToLower(req.URL.Query().Get("ticker"))
+		}
+
+		matches, _ := filepath.Glob(filepath.Join("testdata", name+".*"))
+		if len(matches) == 0 {
+			http.NotFound(writer, req)
+			return
+		}
+
+		content, err := os.ReadFile(matches[0])
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, _ = writer.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestStatusInvest_CrawlerDividendsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should crawl the real-estate fund page of a B3 ticker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := CrawlerDividendsRepository{baseURL: newFixtureServer(t).URL}
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "MXRF11")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), result[0].ExDate)
+		assert.Equal(t, time.Date(2025, time.January, 14, 0, 0, 0, 0, time.UTC), result[0].PaymentDate)
+		assert.InDelta(t, 0.09, result[0].Amount, 0.0001)
+		assert.True(t, result[2].PaymentDate.IsZero())
+	})
+
+	t.Run("should return the errors of every category page when none exists", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := CrawlerDividendsRepository{baseURL: newFixtureServer(t).URL}
+
+		// when
+		_, err := repository.ListDividendsByETF(context.Background(), "BOVA11")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "/fundos-imobiliarios/bova11")
+		assert.Contains(t, err.Error(), "/etfs/bova11")
+	})
+}
+
+func TestStatusInvest_APIPricesRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should read the closing prices of the fetched years", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIPricesRepository(1)
+		repository.baseURL = newFixtureServer(t).URL
+		repository.now = func() time.Time { return time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC) }
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "MXRF11")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		assert.Equal(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), result[0].Date)
+		assert.InDelta(t, 10.35, result[0].Close, 0.001)
+	})
+
+	t.Run("should request the prices of a US ticker in dollars", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		fixtures := newFixtureServer(t)
+
+		var currency string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			currency = req.URL.Query().Get("currences[]")
+			http.Redirect(writer, req, fixtures.URL+req.URL.RequestURI(), http.StatusFound)
+		}))
+		t.Cleanup(server.Close)
+
+		repository := NewAPIPricesRepository(1)
+		repository.baseURL = server.URL
+		repository.now = func() time.Time { return time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC) }
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "2", currency)
+		require.Len(t, result, 2)
+		assert.InDelta(t, 472.65, result[0].Close, 0.001)
+	})
+
+	t.Run("should return an error when the prices are not in the currency of the ticker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIPricesRepository(1)
+		repository.baseURL = newFixtureServer(t).URL
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "QQQ")
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedCurrency)
+	})
+
+	t.Run("should return an error on an unexpected status code", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repository := NewAPIPricesRepository(1)
+		repository.baseURL = newFixtureServer(t).URL
+
+		// when
+		_, err := repository.ListPriceBarsByETF(context.Background(), "UNKNOWN11")
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
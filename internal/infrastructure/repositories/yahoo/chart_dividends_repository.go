@@ -0,0 +1,35 @@
+package yahoo
+
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+type ChartDividendsRepository struct {
+	client *Client
+}
+
+func NewChartDividendsRepository(client *Client) *ChartDividendsRepository {
+	return &ChartDividendsRepository{client: client}
+}
+
+func (r *ChartDividendsRepository) ListDividendsByETF(ctx context.Context, etf string) ([]entities.Dividend, error) {
+	result, err := r.client.history(ctx, etf)
+	if err != nil {
+		return nil, err
+	}
+
+	dividends := make([]entities.Dividend, 0, len(result.Events.Dividends))
+	for _, event := range result.Events.Dividends {
+		dividends = append(dividends, entities.Dividend{
+			ExDate:        result.date(event.Date),
+			Amount:        event.Amount,
+			SplitAdjusted: true,
+		})
+	}
+
+	entities.SortDividends(dividends)
+
+	return dividends, nil
+}
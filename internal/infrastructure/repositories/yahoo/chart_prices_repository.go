@@ -0,0 +1,66 @@
+package yahoo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+type ChartPricesRepository struct {
+	client       *Client
+	yearsToFetch int
+	now          func() time.Time
+}
+
+func NewChartPricesRepository(client *Client, yearsToFetch int) *ChartPricesRepository {
+	return &ChartPricesRepository{client: client, yearsToFetch: yearsToFetch, now: time.Now}
+}
+
+func (r *ChartPricesRepository) ListPriceBarsByETF(ctx context.Context, etf string) ([]entities.PriceBar, error) {
+	now := r.now()
+	from := time.Date(now.Year()-r.yearsToFetch, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := r.client.history(ctx, etf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Indicators.Quote) == 0 {
+		return nil, nil
+	}
+
+	quote := result.Indicators.Quote[0]
+	bars := make([]entities.PriceBar, 0, len(result.Timestamp))
+
+	for index, timestamp := range result.Timestamp {
+		// The days without trades, such as a halted session, come with null values.
+		closePrice := valueAt(quote.Close, index)
+		date := result.date(timestamp)
+		if closePrice == 0 || date.Before(from) {
+			continue
+		}
+
+		bars = append(bars, entities.PriceBar{
+			Date:   date,
+			Open:   valueAt(quote.Open, index),
+			High:   valueAt(quote.High, index),
+			Low:    valueAt(quote.Low, index),
+			Close:  closePrice,
+			Volume: valueAt(quote.Volume, index),
+			// The chart API restates the whole history in the share terms after every split.
+			SplitAdjusted: true,
+		})
+	}
+
+	return bars, nil
+}
+
+// valueAt returns the value at the index, or zero when it is null or missing.
+func valueAt(values []*float64, index int) float64 {
+	if index >= len(values) || values[index] == nil {
+		return 0
+	}
+
+	return *values[index]
+}
@@ -0,0 +1,35 @@
+package yahoo
+
+import (
+	"context"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+type ChartSplitsRepository struct {
+	client *Client
+}
+
+func NewChartSplitsRepository(client *Client) *ChartSplitsRepository {
+	return &ChartSplitsRepository{client: client}
+}
+
+func (r *ChartSplitsRepository) ListSplitsByETF(ctx context.Context, etf string) ([]entities.Split, error) {
+	result, err := r.client.history(ctx, etf)
+	if err != nil {
+		return nil, err
+	}
+
+	splits := make([]entities.Split, 0, len(result.Events.Splits))
+	for _, event := range result.Events.Splits {
+		splits = append(splits, entities.Split{
+			Date:        result.date(event.Date),
+			Numerator:   event.Numerator,
+			Denominator: event.Denominator,
+		})
+	}
+
+	entities.SortSplits(splits)
+
+	return splits, nil
+}
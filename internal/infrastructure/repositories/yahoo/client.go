@@ -0,0 +1,199 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rios0rios0/investmate/internal/domain/entities"
+)
+
+const (
+	// DefaultBaseURL is the address of the Yahoo Finance chart API.
+	DefaultBaseURL = "https://query1.finance.yahoo.com"
+
+	// userAgent is a browser-like user agent, since the chart API rejects requests without one.
+	userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 " +
+		"(KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.0.0"
+
+	// bodyExcerptLength is the number of bytes of an error response body kept in the returned error.
+	bodyExcerptLength = 256
+
+	// b3Suffix is appended to the B3 tickers, which Yahoo lists under the São Paulo exchange.
+	b3Suffix = ".SA"
+)
+
+var (
+	// ErrUnexpectedStatus is returned when the chart API answers with a status code that is not successful.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+
+	// ErrChartNotFound is returned when the chart API reports an error or no result for the symbol.
+	ErrChartNotFound = errors.New("chart not found")
+)
+
+// chartResponse is the body of the chart endpoint: the daily bars plus the dividend and split events.
+type chartResponse struct {
+	Chart struct {
+		Result []chartResult `json:"result"`
+		Error  *struct {
+			Code        string `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+type chartResult struct {
+	Meta struct {
+		Currency  string `json:"currency"`
+		Symbol    string `json:"symbol"`
+		GMTOffset int    `json:"gmtoffset"` // Seconds east of UTC of the exchange.
+	} `json:"meta"`
+	Timestamp []int64 `json:"timestamp"`
+	Events    struct {
+		Dividends map[string]struct {
+			Amount float64 `json:"amount"`
+			Date   int64   `json:"date"`
+		} `json:"dividends"`
+		Splits map[string]struct {
+			Date        int64   `json:"date"`
+			Numerator   float64 `json:"numerator"`
+			Denominator float64 `json:"denominator"`
+		} `json:"splits"`
+	} `json:"events"`
+	Indicators struct {
+		Quote []struct {
+			Open   []*float64 `json:"open"`
+			High   []*float64 `json:"high"`
+			Low    []*float64 `json:"low"`
+			Close  []*float64 `json:"close"`
+			Volume []*float64 `json:"volume"`
+		} `json:"quote"`
+	} `json:"indicators"`
+}
+
+// date converts a timestamp of the response into the trading date at the exchange, at midnight UTC like the
+// dates of the other providers.
+func (r chartResult) date(timestamp int64) time.Time {
+	local := time.Unix(timestamp, 0).In(time.FixedZone(r.Meta.Symbol, r.Meta.GMTOffset))
+
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Client is the HTTP client shared by the Yahoo repositories.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mutex  sync.Mutex
+	charts map[string]*memoizedChart
+}
+
+// memoizedChart holds the chart of a symbol once it was fetched, and serializes the callers waiting for it.
+type memoizedChart struct {
+	mutex   sync.Mutex
+	result  chartResult
+	fetched bool
+}
+
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{baseURL: baseURL, httpClient: httpClient, charts: make(map[string]*memoizedChart)}
+}
+
+// history returns the whole daily chart of the ticker with its dividend and split events. A single response carries
+// the bars, the dividends and the splits, so it is requested only once per symbol and shared by the repositories of
+// the client. A failed request is not kept, so the next caller tries again.
+func (c *Client) history(ctx context.Context, ticker string) (chartResult, error) {
+	symbol := symbolOf(ticker)
+
+	c.mutex.Lock()
+	memoized, exists := c.charts[symbol]
+	if !exists {
+		memoized = &memoizedChart{}
+		c.charts[symbol] = memoized
+	}
+	c.mutex.Unlock()
+
+	memoized.mutex.Lock()
+	defer memoized.mutex.Unlock()
+
+	if memoized.fetched {
+		return memoized.result, nil
+	}
+
+	result, err := c.chart(ctx, symbol, url.Values{"range": {"max"}, "interval": {"1d"}})
+	if err != nil {
+		return chartResult{}, err
+	}
+
+	memoized.result, memoized.fetched = result, true
+
+	return result, nil
+}
+
+// symbolOf returns the symbol Yahoo lists the ticker under, adding the exchange suffix to the B3 tickers.
+func symbolOf(ticker string) string {
+	if entities.MarketOf(ticker) == entities.MarketB3 {
+		return ticker + b3Suffix
+	}
+
+	return ticker
+}
+
+// chart requests the chart of the symbol with the given query parameters.
+func (c *Client) chart(ctx context.Context, symbol string, query url.Values) (chartResult, error) {
+	query.Set("events", "div,split")
+
+	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s?%s", c.baseURL, url.PathEscape(symbol), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return chartResult{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return chartResult{}, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// The chart API also answers 404 with a JSON error body, which names the problem better than the status.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, bodyExcerptLength))
+		return chartResult{}, fmt.Errorf("%w: %d for %s: %s", ErrUnexpectedStatus, resp.StatusCode, symbol, excerpt)
+	}
+
+	var response chartResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return chartResult{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if response.Chart.Error != nil {
+		return chartResult{}, fmt.Errorf(
+			"%w for %s: %s", ErrChartNotFound, symbol, response.Chart.Error.Description,
+		)
+	}
+
+	if len(response.Chart.Result) == 0 {
+		return chartResult{}, fmt.Errorf("%w for %s", ErrChartNotFound, symbol)
+	}
+
+	return response.Chart.Result[0], nil
+}
@@ -0,0 +1,200 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureServer serves the recorded fixture whose name matches the requested interval.
+func newFixtureServer(t *testing.T, fixtures map[string]string, status int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		assert.True(t, strings.HasPrefix(req.URL.Path, "/v8/finance/chart/"))
+		assert.Equal(t, "div,split", req.URL.Query().Get("events"))
+		assert.Equal(t, "max", req.URL.Query().Get("range"))
+
+		content, err := os.ReadFile(filepath.Join("testdata", fixtures[req.URL.Query().Get("interval")]))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		writer.WriteHeader(status)
+		_, _ = writer.Write(content)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestYahoo_ChartPricesRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should convert the daily bars at the exchange date and skip the null sessions", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newFixtureServer(t, map[string]string{"1d": "chart_spy_daily.json"}, http.StatusOK)
+		repository := NewChartPricesRepository(NewClient(server.URL, server.Client()), 1)
+		repository.now = func() time.Time { return time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC) }
+
+		// when
+		result, err := repository.ListPriceBarsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.Equal(t, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), result[0].Date)
+		assert.InDelta(t, 584.64, result[0].Close, 0.001)
+		assert.InDelta(t, 50203975, result[0].Volume, 0.001)
+		assert.Equal(t, time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC), result[3].Date)
+	})
+}
+
+func TestYahoo_ChartDividendsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the dividend events sorted by ex-date", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newFixtureServer(t, map[string]string{"1d": "chart_spy_events.json"}, http.StatusOK)
+		repository := NewChartDividendsRepository(NewClient(server.URL, server.Client()))
+
+		// when
+		result, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), result[0].ExDate)
+		assert.InDelta(t, 1.5943, result[0].Amount, 0.0001)
+		assert.Equal(t, time.Date(2024, time.December, 20, 0, 0, 0, 0, time.UTC), result[3].ExDate)
+	})
+
+	t.Run("should return the error described by the chart API", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newFixtureServer(t, map[string]string{"1d": "chart_not_found.json"}, http.StatusNotFound)
+		repository := NewChartDividendsRepository(NewClient(server.URL, server.Client()))
+
+		// when
+		_, err := repository.ListDividendsByETF(context.Background(), "NOPE")
+
+		// then
+		require.ErrorIs(t, err, ErrChartNotFound)
+		assert.Contains(t, err.Error(), "delisted")
+	})
+
+	t.Run("should return an error on an unexpected status code", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newFixtureServer(t, map[string]string{"1d": "chart_spy_events.json"}, http.StatusTooManyRequests)
+		repository := NewChartDividendsRepository(NewClient(server.URL, server.Client()))
+
+		// when
+		_, err := repository.ListDividendsByETF(context.Background(), "SPY")
+
+		// then
+		require.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
+
+func TestYahoo_ChartSplitsRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the split events sorted by date at the exchange date", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newFixtureServer(t, map[string]string{"1d": "chart_svol_events.json"}, http.StatusOK)
+		repository := NewChartSplitsRepository(NewClient(server.URL, server.Client()))
+
+		// when
+		result, err := repository.ListSplitsByETF(context.Background(), "SVOL")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, time.Date(2022, time.May, 16, 0, 0, 0, 0, time.UTC), result[0].Date)
+		assert.InDelta(t, 2.0, result[0].Ratio(), 0.0001)
+		assert.Equal(t, time.Date(2024, time.August, 5, 0, 0, 0, 0, time.UTC), result[1].Date)
+		assert.InDelta(t, 0.25, result[1].Ratio(), 0.0001)
+	})
+}
+
+func TestYahoo_Client(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should request the chart once for the prices, dividends and splits of a symbol", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+
+			content, err := os.ReadFile(filepath.Join("testdata", "chart_spy_events.json"))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, _ = writer.Write(content)
+		}))
+		t.Cleanup(server.Close)
+
+		client := NewClient(server.URL, server.Client())
+		prices := NewChartPricesRepository(client, 5)
+		dividends := NewChartDividendsRepository(client)
+		splits := NewChartSplitsRepository(client)
+
+		// when
+		_, pricesErr := prices.ListPriceBarsByETF(context.Background(), "SPY")
+		result, dividendsErr := dividends.ListDividendsByETF(context.Background(), "SPY")
+		_, splitsErr := splits.ListSplitsByETF(context.Background(), "SPY")
+
+		// then
+		require.NoError(t, pricesErr)
+		require.NoError(t, dividendsErr)
+		require.NoError(t, splitsErr)
+		assert.Len(t, result, 4)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+	t.Run("should request the B3 tickers with the exchange suffix", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/v8/finance/chart/MXRF11.SA", req.URL.Path)
+
+			content, err := os.ReadFile(filepath.Join("testdata", "chart_spy_events.json"))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, _ = writer.Write(content)
+		}))
+		t.Cleanup(server.Close)
+
+		repository := NewChartSplitsRepository(NewClient(server.URL, server.Client()))
+
+		// when
+		_, err := repository.ListSplitsByETF(context.Background(), "MXRF11")
+
+		// then
+		require.NoError(t, err)
+	})
+}